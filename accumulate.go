@@ -0,0 +1,30 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Accumulate runs produce in a new goroutine, folding each item it passes to
+// emit into an accumulator that starts out as init. Once produce returns, the
+// promise resolves with the final accumulator, or rejects if produce returned
+// an error. emit may safely be called from multiple goroutines, but must not be
+// called after produce has returned.
+func Accumulate[T, A any](ctx context.Context, produce func(ctx context.Context, emit func(T)) error, init A, fold func(A, T) A) Promise[A] {
+	return NewPromise(func() (A, error) {
+		var mu sync.Mutex
+		acc := init
+		emit := func(v T) {
+			mu.Lock()
+			defer mu.Unlock()
+			acc = fold(acc, v)
+		}
+		if err := produce(ctx, emit); err != nil {
+			var zerov A
+			return zerov, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return acc, nil
+	})
+}
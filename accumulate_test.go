@@ -0,0 +1,28 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAccumulate(t *testing.T) {
+	ctx := context.Background()
+	sum := func(a, v int) int { return a + v }
+	promise := Accumulate(ctx, func(ctx context.Context, emit func(int)) error {
+		for page := 1; page <= 4; page++ {
+			emit(page * 10)
+		}
+		return nil
+	}, 0, sum)
+	v, err := promise.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 100, v)
+
+	promise = Accumulate(ctx, func(ctx context.Context, emit func(int)) error {
+		emit(1)
+		return errors.New("page 2 failed")
+	}, 0, sum)
+	_, err = promise.Await(ctx)
+	requireError(t, err)
+}
@@ -0,0 +1,20 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// AfterAll runs fn once every one of deps has settled successfully, and
+// resolves with its result. fn is given a context like the one
+// NewPromiseContext provides. Should any dependency fail, the promise rejects
+// with its error and fn is never run.
+func AfterAll[T any](ctx context.Context, deps []Promise[struct{}], fn func(context.Context) (T, error)) Promise[T] {
+	return NewPromiseContext(ctx, func(ctx context.Context) (T, error) {
+		if _, err := All(ctx, deps); err != nil {
+			var zerov T
+			return zerov, errors.Unwrap(err)
+		}
+		return fn(ctx)
+	})
+}
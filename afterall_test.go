@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAfterAll(t *testing.T) {
+	ctx := context.Background()
+	var settled atomic.Int32
+	dep := func(d time.Duration) Promise[struct{}] {
+		return NewPromise(func() (struct{}, error) {
+			time.Sleep(d)
+			settled.Add(1)
+			return struct{}{}, nil
+		})
+	}
+	v, err := AfterAll(ctx, []Promise[struct{}]{
+		dep(time.Millisecond * 10),
+		dep(time.Millisecond * 30),
+		dep(0),
+	}, func(context.Context) (int32, error) {
+		return settled.Load(), nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int32(3), v)
+
+	doh := errors.New("doh!")
+	_, err = AfterAll(ctx, []Promise[struct{}]{
+		dep(0),
+		Reject[struct{}](doh),
+	}, func(context.Context) (int32, error) {
+		t.Error("fn should not run when a dependency fails")
+		return 0, nil
+	}).Await(ctx)
+	requireEqual(t, doh, err)
+}
@@ -0,0 +1,21 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// AfterAwait awaits p and runs after exactly once, as soon as either the await
+// completes or ctx is cancelled, whichever happens first. It is built upon
+// context.AfterFunc, so a cancellation of ctx triggers after right away, on
+// its own goroutine, rather than once the await notices. after is guaranteed
+// to have finished running by the time AfterAwait returns.
+func AfterAwait[T any](ctx context.Context, p Promise[T], after func()) (T, error) {
+	var once sync.Once
+	run := func() { once.Do(after) }
+	stop := context.AfterFunc(ctx, run)
+	v, err := p.Await(ctx)
+	stop()
+	run()
+	return v, err
+}
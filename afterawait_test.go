@@ -0,0 +1,23 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAfterAwait(t *testing.T) {
+	var calls atomic.Int32
+	after := func() { calls.Add(1) }
+	v, err := AfterAwait(context.Background(), Resolve(1), after)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+	requireEqual(t, int32(1), calls.Load())
+
+	calls.Store(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = AfterAwait[int](ctx, newCancelProbe[int](), after)
+	requireEqual(t, context.Canceled, err)
+	requireEqual(t, int32(1), calls.Load())
+}
@@ -0,0 +1,81 @@
+package async
+
+import (
+	"context"
+)
+
+// allOf runs each of the waiters concurrently, returning the first error any
+// of them produces after cancelling the context given to the rest. Like All,
+// it does not return until every waiter has, so that none of them outlive it.
+func allOf(ctx context.Context, waiters ...func(context.Context) error) error {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	errc := make(chan error, len(waiters))
+	for _, wait := range waiters {
+		go func(wait func(context.Context) error) {
+			errc <- wait(ctx)
+		}(wait)
+	}
+	for i := range waiters {
+		if err := <-errc; err != nil {
+			cancel()
+			for ; i < len(waiters)-1; i++ {
+				<-errc
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// awaitInto returns a waiter for allOf that stores the value of p in v.
+func awaitInto[T any](p Promise[T], v *T) func(context.Context) error {
+	return func(ctx context.Context) (err error) {
+		*v, err = p.Await(ctx)
+		return err
+	}
+}
+
+// All2 awaits two promises of different types, like All does for promises of
+// the same type: the first error short-circuits and cancels the other await.
+func All2[A, B any](ctx context.Context, a Promise[A], b Promise[B]) (A, B, error) {
+	var va A
+	var vb B
+	if err := allOf(ctx, awaitInto(a, &va), awaitInto(b, &vb)); err != nil {
+		var za A
+		var zb B
+		return za, zb, err
+	}
+	return va, vb, nil
+}
+
+// All3 is like All2, for three promises.
+func All3[A, B, C any](ctx context.Context, a Promise[A], b Promise[B], c Promise[C]) (A, B, C, error) {
+	var va A
+	var vb B
+	var vc C
+	if err := allOf(ctx, awaitInto(a, &va), awaitInto(b, &vb), awaitInto(c, &vc)); err != nil {
+		var za A
+		var zb B
+		var zc C
+		return za, zb, zc, err
+	}
+	return va, vb, vc, nil
+}
+
+// All4 is like All2, for four promises.
+func All4[A, B, C, D any](ctx context.Context, a Promise[A], b Promise[B], c Promise[C], d Promise[D]) (A, B, C, D, error) {
+	var va A
+	var vb B
+	var vc C
+	var vd D
+	if err := allOf(ctx, awaitInto(a, &va), awaitInto(b, &vb), awaitInto(c, &vc), awaitInto(d, &vd)); err != nil {
+		var za A
+		var zb B
+		var zc C
+		var zd D
+		return za, zb, zc, zd, err
+	}
+	return va, vb, vc, vd, nil
+}
@@ -0,0 +1,46 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAll2(t *testing.T) {
+	ctx := context.Background()
+	name, age, err := All2(ctx, Resolve("alice"), Resolve(30))
+	requireNoError(t, err)
+	requireEqual(t, "alice", name)
+	requireEqual(t, 30, age)
+
+	probe := newCancelProbe[int]()
+	_, _, err = All2[string, int](ctx, Reject[string](errors.New("doh!")), probe)
+	requireError(t, err)
+	select {
+	case <-probe.cancelled:
+	default:
+		t.Fatal("expected All2 to wait for the cancelled await")
+	}
+}
+
+func TestAll3(t *testing.T) {
+	ctx := context.Background()
+	a, b, c, err := All3(ctx, Resolve("a"), Resolve(2), Resolve(true))
+	requireNoError(t, err)
+	requireEqual(t, "a", a)
+	requireEqual(t, 2, b)
+	requireEqual(t, true, c)
+}
+
+func TestAll4(t *testing.T) {
+	ctx := context.Background()
+	a, b, c, d, err := All4(ctx, Resolve("a"), Resolve(2), Resolve(true), Resolve(4.0))
+	requireNoError(t, err)
+	requireEqual(t, "a", a)
+	requireEqual(t, 2, b)
+	requireEqual(t, true, c)
+	requireEqual(t, 4.0, d)
+
+	_, _, _, _, err = All4(ctx, Resolve("a"), Resolve(2), Resolve(true), Reject[float64](errors.New("doh!")))
+	requireError(t, err)
+}
@@ -0,0 +1,33 @@
+package async
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypeAssertionError is the error a promise returned by Assert is rejected
+// with when the value is not of the asserted type.
+type TypeAssertionError struct {
+	// Value is the value that failed the assertion.
+	Value any
+
+	// Expected is the type it was asserted to be.
+	Expected reflect.Type
+}
+
+func (e *TypeAssertionError) Error() string {
+	return fmt.Sprintf("async: promise value of type %T is not %s", e.Value, e.Expected)
+}
+
+// Assert converts a promise of an untyped value into a promise of T, by
+// asserting that the value is a T. If it is not, the promise rejects with a
+// *TypeAssertionError. Errors from p are passed through unchanged.
+func Assert[T any](p Promise[any]) Promise[T] {
+	return Then(p, func(v any) (T, error) {
+		t, ok := v.(T)
+		if !ok {
+			return t, &TypeAssertionError{Value: v, Expected: reflect.TypeFor[T]()}
+		}
+		return t, nil
+	})
+}
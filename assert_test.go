@@ -0,0 +1,24 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAssert(t *testing.T) {
+	ctx := context.Background()
+	v, err := Assert[string](Resolve[any]("typed")).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "typed", v)
+
+	_, err = Assert[string](Resolve[any](42)).Await(ctx)
+	var assertErr *TypeAssertionError
+	requireEqual(t, true, errors.As(err, &assertErr))
+	requireEqual(t, any(42), assertErr.Value)
+	requireEqual(t, "async: promise value of type int is not string", err.Error())
+
+	doh := errors.New("doh!")
+	_, err = Assert[string](Reject[any](doh)).Await(ctx)
+	requireEqual(t, doh, err)
+}
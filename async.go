@@ -2,6 +2,10 @@ package async // import "code.nkcmr.net/async"
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Promise is an abstract representation of a value that might eventually be
@@ -20,14 +24,65 @@ type Promise[T any] interface {
 }
 
 type syncPromise[T any] struct {
+	once sync.Once
 	done chan struct{}
 	v    T
 	err  error
+
+	// settled is set once v and err are, letting Await skip the select for a
+	// promise that has already settled.
+	settled atomic.Bool
+
+	mu        sync.Mutex
+	callbacks []func(T, error)
+}
+
+func newSyncPromise[T any]() *syncPromise[T] {
+	return &syncPromise[T]{
+		done: make(chan struct{}),
+	}
+}
+
+// settle delivers the result to all waiters. Only the first call to settle has
+// any effect; the result of a promise never changes once it is settled.
+func (s *syncPromise[T]) settle(v T, err error) {
+	s.once.Do(func() {
+		s.mu.Lock()
+		s.v, s.err = v, err
+		s.settled.Store(true)
+		close(s.done)
+		callbacks := s.callbacks
+		s.callbacks = nil
+		s.mu.Unlock()
+		for _, fn := range callbacks {
+			fn(v, err)
+		}
+	})
+}
+
+// onSettle arranges for fn to be called with the result once the promise has
+// settled, or calls it right away if it already has.
+func (s *syncPromise[T]) onSettle(fn func(T, error)) {
+	s.mu.Lock()
+	select {
+	case <-s.done:
+		s.mu.Unlock()
+		fn(s.v, s.err)
+	default:
+		s.callbacks = append(s.callbacks, fn)
+		s.mu.Unlock()
+	}
 }
 
 func (s *syncPromise[T]) Await(ctx context.Context) (T, error) {
+	if s.settled.Load() {
+		return s.v, s.err
+	}
 	select {
 	case <-ctx.Done():
+		if h := hooks.Load(); h != nil && h.AwaitCancelled != nil {
+			h.AwaitCancelled(ctx.Err())
+		}
 		var zerov T
 		return zerov, ctx.Err()
 	case <-s.done:
@@ -36,24 +91,22 @@ func (s *syncPromise[T]) Await(ctx context.Context) (T, error) {
 }
 
 func (s *syncPromise[T]) Settled() bool {
-	select {
-	case <-s.done:
-		return true
-	default:
-		return false
-	}
+	return s.settled.Load()
 }
 
 // NewPromise wraps a function in a goroutine that will make the result of that
-// function deliver its result to the holder of the promise.
-func NewPromise[T any](fn func() (T, error)) Promise[T] {
-	c := &syncPromise[T]{
-		done: make(chan struct{}),
+// function deliver its result to the holder of the promise. Should the function
+// panic, the panic is recovered and the promise rejects with a *PanicError.
+// How the function is run may be tuned with opts.
+func NewPromise[T any](fn func() (T, error), opts ...Option) Promise[T] {
+	c := newSyncPromise[T]()
+	if len(opts) == 0 && hooks.Load() == nil {
+		launch(func() {
+			c.settle(safeCall(fn))
+		})
+		return c
 	}
-	go func() {
-		c.v, c.err = fn()
-		close(c.done)
-	}()
+	run(opts, fn, c.settle)
 	return c
 }
 
@@ -81,26 +134,99 @@ func Reject[T any](err error) Promise[T] {
 }
 
 // All takes a slice of promises and will await the result of all of the
-// specified promises. If any promise should return an error, the wh
+// specified promises. If any promise should return an error, the whole
+// operation is failed with an IndexedError recording which promise failed, and
+// the awaits of the remaining promises are cancelled. All does not return
+// until every one of those awaits has returned, so that none of its goroutines
+// outlive it; for this, the promises must honour the cancellation of the
+// context they are awaited with, as all of the promises in this package do.
 func All[T any](ctx context.Context, promises []Promise[T]) ([]T, error) {
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 	defer cancel()
 	out := make([]T, len(promises))
-	errc := make(chan error, len(out))
-	waiter := func(i int, p Promise[T]) {
-		var err error
-		out[i], err = p.Await(ctx)
-		errc <- err
-	}
-	for i := range out {
-		go waiter(i, promises[i])
-	}
-	for i := 0; i < len(out); i++ {
-		if err := <-errc; err != nil {
+	results := awaitEach(ctx, promises)
+	for i := range promises {
+		r := <-results
+		if r.err != nil {
 			cancel()
-			return nil, err
+			for ; i < len(promises)-1; i++ {
+				<-results
+			}
+			return nil, IndexedError{Index: r.index, Err: r.err}
 		}
+		out[r.index] = r.v
 	}
 	return out, nil
 }
+
+// AllJoinErrors is like All, except that a failure does not cut the others
+// short. The values are returned in input order, with the zero value in the
+// slot of every promise that failed, along with the errors of all of the
+// failures, each an IndexedError, joined together.
+func AllJoinErrors[T any](ctx context.Context, promises []Promise[T]) ([]T, error) {
+	out := make([]T, len(promises))
+	errs := make([]error, len(promises))
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			errs[r.index] = IndexedError{Index: r.index, Err: r.err}
+			continue
+		}
+		out[r.index] = r.v
+	}
+	return out, errors.Join(errs...)
+}
+
+// outcome is the result of awaiting a single promise out of a group, tagged
+// with the position of that promise in the group.
+type outcome[T any] struct {
+	index int
+	v     T
+	err   error
+}
+
+// awaitEach awaits every promise in its own goroutine, or in place should it
+// have already settled, and delivers each outcome on the returned channel as
+// it becomes available. The channel is buffered so that no waiter blocks if
+// the caller stops receiving early; callers should cancel ctx to unblock
+// waiters that are still pending.
+func awaitEach[T any](ctx context.Context, promises []Promise[T]) <-chan outcome[T] {
+	out := make(chan outcome[T], len(promises))
+	for i, p := range promises {
+		if p.Settled() {
+			// There is nothing to wait for, so spare the goroutine.
+			v, err := p.Await(ctx)
+			out <- outcome[T]{index: i, v: v, err: err}
+			continue
+		}
+		go func(i int, p Promise[T]) {
+			v, err := p.Await(ctx)
+			out <- outcome[T]{index: i, v: v, err: err}
+		}(i, p)
+	}
+	return out
+}
+
+// awaitPolling awaits p, calling check every interval while it waits. Should
+// check return an error, the await is abandoned with that error.
+func awaitPolling[T any](ctx context.Context, p Promise[T], interval time.Duration, check func() error) (T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	result := awaitEach(ctx, []Promise[T]{p})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case r := <-result:
+			return r.v, r.err
+		case <-ticker.C:
+			if err := check(); err != nil {
+				var zerov T
+				return zerov, err
+			}
+		}
+	}
+}
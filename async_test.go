@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -71,6 +72,43 @@ func TestAll(t *testing.T) {
 	ints, err = All(ctx, promises)
 	requireError(t, err)
 	requireEqual(t, ints, nil)
+	var indexErr IndexedError
+	requireEqual(t, true, errors.As(err, &indexErr))
+	requireEqual(t, 1, indexErr.Index)
+	requireEqual(t, "doh!", indexErr.Err.Error())
+}
+
+func TestAllWaitsForCancelledAwaits(t *testing.T) {
+	probe := newCancelProbe[int]()
+	_, err := All(context.Background(), []Promise[int]{probe, Reject[int](errors.New("doh!"))})
+	requireError(t, err)
+	select {
+	case <-probe.cancelled:
+	default:
+		t.Fatal("expected the cancelled await to have returned before All did")
+	}
+}
+
+func TestAllJoinErrors(t *testing.T) {
+	ctx := context.Background()
+	doh, darn := errors.New("doh!"), errors.New("darn")
+	ints, err := AllJoinErrors(ctx, []Promise[int]{
+		Resolve(1),
+		Reject[int](doh),
+		Resolve(3),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 0, darn
+		}),
+	})
+	requireEqual(t, []int{1, 0, 3, 0}, ints)
+	requireEqual(t, true, errors.Is(err, doh))
+	requireEqual(t, true, errors.Is(err, darn))
+	requireEqual(t, "promise 1: doh!\npromise 3: darn", err.Error())
+
+	ints, err = AllJoinErrors(ctx, []Promise[int]{Resolve(1), Resolve(2)})
+	requireNoError(t, err)
+	requireEqual(t, []int{1, 2}, ints)
 }
 
 func TestResolve(t *testing.T) {
@@ -116,3 +154,96 @@ func requireEqual[T any](t *testing.T, expected, actual T) {
 		t.Fatalf(`expected "%v" got "%v"`, expected, actual)
 	}
 }
+
+// cancelProbe is a promise that never settles on its own, but reports when a
+// caller's await of it has been cancelled.
+type cancelProbe[T any] struct {
+	cancelled chan struct{}
+}
+
+func newCancelProbe[T any]() *cancelProbe[T] {
+	return &cancelProbe[T]{cancelled: make(chan struct{})}
+}
+
+func (c *cancelProbe[T]) Settled() bool { return false }
+
+func (c *cancelProbe[T]) Await(ctx context.Context) (T, error) {
+	<-ctx.Done()
+	close(c.cancelled)
+	var zerov T
+	return zerov, ctx.Err()
+}
+
+func requireCancelled[T any](t *testing.T, c *cancelProbe[T]) {
+	t.Helper()
+	select {
+	case <-c.cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("expected await to be cancelled")
+	}
+}
+
+// trackPeak counts one more piece of work as running, raising peak to match
+// should it be the most seen at once, and returns the func that counts it as
+// done again.
+func trackPeak(running, peak *atomic.Int32) func() {
+	n := running.Add(1)
+	for {
+		p := peak.Load()
+		if n <= p || peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	return func() { running.Add(-1) }
+}
+
+var benchmarkSink Promise[int]
+
+func BenchmarkResolve(b *testing.B) {
+	b.ReportAllocs()
+	for range b.N {
+		benchmarkSink = Resolve(1)
+	}
+}
+
+func BenchmarkNewPromise(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	for range b.N {
+		NewPromise(func() (int, error) { return 1, nil }).Await(ctx)
+	}
+}
+
+func BenchmarkAwaitSettled(b *testing.B) {
+	ctx := context.Background()
+	p := newSyncPromise[int]()
+	p.settle(1, nil)
+	b.ReportAllocs()
+	for range b.N {
+		p.Await(ctx)
+	}
+}
+
+func BenchmarkAllSettled(b *testing.B) {
+	ctx := context.Background()
+	promises := make([]Promise[int], 100)
+	for i := range promises {
+		promises[i] = Resolve(i)
+	}
+	b.ReportAllocs()
+	for range b.N {
+		All(ctx, promises)
+	}
+}
+
+func BenchmarkAllPending(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	for range b.N {
+		promises := make([]Promise[int], 100)
+		for i := range promises {
+			promises[i] = NewPromise(func() (int, error) { return i, nil })
+		}
+		All(ctx, promises)
+	}
+}
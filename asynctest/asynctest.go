@@ -0,0 +1,209 @@
+// Package asynctest provides helpers for testing code built on the async
+// package deterministically: a fake Clock that only moves when told to, an
+// Executor that runs work one step at a time, and assertions on promises.
+package asynctest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/async"
+)
+
+// FakeClock is an async.Clock whose time only moves when Advance is called.
+// Timers due by then fire in order of their deadlines, before Advance returns.
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock creates a FakeClock that starts at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Install makes c the clock of the async package for the rest of the test,
+// restoring the real clock once it is done.
+func (c *FakeClock) Install(t testing.TB) {
+	async.SetClock(c)
+	t.Cleanup(func() { async.SetClock(nil) })
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a timer that delivers the time on its channel once the
+// clock has been advanced by d.
+func (c *FakeClock) NewTimer(d time.Duration) async.Timer {
+	return c.schedule(d, make(chan time.Time, 1), nil)
+}
+
+// AfterFunc returns a timer that calls f on a goroutine of its own once the
+// clock has been advanced by d.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) async.Timer {
+	return c.schedule(d, nil, f)
+}
+
+func (c *FakeClock) schedule(d time.Duration, ch chan time.Time, f func()) *fakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, when: c.now.Add(d), c: ch, f: f}
+	if d <= 0 {
+		t.fire(c.now)
+		return t
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance moves the clock forward by d, firing every timer that falls due on
+// the way, in order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	target := c.now.Add(d)
+	sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].when.Before(c.timers[j].when) })
+	for len(c.timers) > 0 && !c.timers[0].when.After(target) {
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = t.when
+		t.fire(t.when)
+	}
+	c.now = target
+}
+
+// BlockUntil waits until at least n timers are waiting on the clock, for
+// when the code under test schedules its timers on goroutines of its own.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	c     chan time.Time
+	f     func()
+}
+
+// fire is called with the clock locked.
+func (t *fakeTimer) fire(now time.Time) {
+	if t.f != nil {
+		go t.f()
+		return
+	}
+	t.c <- now
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Executor is an async.Executor that queues work instead of running it, so
+// that a test decides when, and in what order, each piece of work runs. The
+// zero value is ready to use.
+type Executor struct {
+	mu    sync.Mutex
+	queue []func()
+}
+
+// Install makes e the default executor of the async package for the rest of
+// the test, restoring the default once it is done.
+func (e *Executor) Install(t testing.TB) {
+	async.SetDefaultExecutor(e)
+	t.Cleanup(func() { async.SetDefaultExecutor(nil) })
+}
+
+// Go queues fn.
+func (e *Executor) Go(fn func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.queue = append(e.queue, fn)
+}
+
+// Pending returns how many functions are queued.
+func (e *Executor) Pending() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.queue)
+}
+
+// RunNext runs the function that was queued first, on the calling goroutine,
+// reporting false if there was none.
+func (e *Executor) RunNext() bool {
+	e.mu.Lock()
+	if len(e.queue) == 0 {
+		e.mu.Unlock()
+		return false
+	}
+	fn := e.queue[0]
+	e.queue = e.queue[1:]
+	e.mu.Unlock()
+	fn()
+	return true
+}
+
+// RunAll runs queued functions until there are none left, including any that
+// they queue themselves, and returns how many it ran.
+func (e *Executor) RunAll() int {
+	n := 0
+	for e.RunNext() {
+		n++
+	}
+	return n
+}
+
+// AwaitWithin awaits p for up to d of real time, failing the test if it does
+// not resolve by then, or rejects.
+func AwaitWithin[T any](t testing.TB, p async.Promise[T], d time.Duration) T {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	v, err := p.Await(ctx)
+	if err != nil {
+		t.Fatalf("promise did not resolve within %s: %v", d, err)
+	}
+	return v
+}
+
+// RejectsWithin awaits p for up to d of real time, failing the test if it
+// does not reject by then, and returns its error.
+func RejectsWithin[T any](t testing.TB, p async.Promise[T], d time.Duration) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	v, err := p.Await(ctx)
+	if err == nil {
+		t.Fatalf("promise resolved with %v, expected it to reject", v)
+	}
+	if ctx.Err() != nil && !p.Settled() {
+		t.Fatalf("promise did not reject within %s", d)
+	}
+	return err
+}
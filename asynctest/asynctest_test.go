@@ -0,0 +1,126 @@
+package asynctest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/async"
+)
+
+func TestFakeClockTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	clock.Install(t)
+
+	never, _ := async.NewDeferred[int]()
+	p := async.WithTimeout(never, time.Hour)
+	go p.Await(context.Background())
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute * 59)
+	if p.Settled() {
+		t.Fatal("promise timed out early")
+	}
+	clock.Advance(time.Minute)
+	err := RejectsWithin(t, p, time.Second)
+	var timeoutErr *async.TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.After != time.Hour {
+		t.Fatalf("expected a timeout after an hour, got %v", err)
+	}
+}
+
+func TestFakeClockRetry(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	clock.Install(t)
+
+	ctx := context.Background()
+	calls := 0
+	p := async.Retry(ctx, 3, func(int) time.Duration { return time.Minute }, func(context.Context) (int, error) {
+		if calls++; calls < 3 {
+			return 0, errors.New("flaky")
+		}
+		return calls, nil
+	})
+	for range 2 {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}
+	if v := AwaitWithin(t, p, time.Second); v != 3 {
+		t.Fatalf("expected 3 calls, got %d", v)
+	}
+	if got := clock.Now(); !got.Equal(time.Unix(120, 0)) {
+		t.Fatalf("unexpected time %v", got)
+	}
+}
+
+func TestFakeClockDebounce(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	clock.Install(t)
+
+	ctx := context.Background()
+	runs := 0
+	refresh := async.Debounce(time.Second, func(context.Context) (int, error) {
+		runs++
+		return runs, nil
+	})
+	first := refresh(ctx)
+	clock.Advance(time.Millisecond * 600)
+	second := refresh(ctx)
+	clock.Advance(time.Millisecond * 600)
+	if first != second || first.Settled() {
+		t.Fatal("expected the calls to share a pending run")
+	}
+	clock.BlockUntil(1)
+	clock.Advance(time.Millisecond * 400)
+	if v := AwaitWithin(t, second, time.Second); v != 1 {
+		t.Fatalf("expected a single run, got %d", v)
+	}
+}
+
+func TestExecutor(t *testing.T) {
+	var e Executor
+	e.Install(t)
+
+	var order []int
+	a := async.NewPromise(func() (int, error) { order = append(order, 1); return 1, nil })
+	b := async.NewPromise(func() (int, error) { order = append(order, 2); return 2, nil })
+	if e.Pending() != 2 || a.Settled() || b.Settled() {
+		t.Fatal("expected the work to be queued")
+	}
+	if !e.RunNext() || !a.Settled() || b.Settled() {
+		t.Fatal("expected only the first promise to settle")
+	}
+	if n := e.RunAll(); n != 1 {
+		t.Fatalf("expected 1 more run, got %d", n)
+	}
+	AwaitWithin(t, b, time.Second)
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Fatalf("unexpected order %v", order)
+	}
+}
+
+func TestFakeClockRetryDeadline(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	clock.Install(t)
+
+	ctx := context.Background()
+	p := async.RetryWithPolicy(ctx, async.RetryPolicy{
+		MaxAttempts: 10,
+		Deadline:    time.Minute,
+		Backoff:     func(int) time.Duration { return time.Second * 45 },
+	}, func(context.Context) (int, error) {
+		return 0, errors.New("flaky")
+	})
+	clock.BlockUntil(2)
+	clock.Advance(time.Second * 45)
+	clock.BlockUntil(2)
+	clock.Advance(time.Second * 15)
+	err := RejectsWithin(t, p, time.Second)
+	var retryErr *async.RetryError
+	if !errors.As(err, &retryErr) || retryErr.Err != context.DeadlineExceeded {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	if retryErr.Attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", retryErr.Attempts)
+	}
+}
@@ -0,0 +1,56 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+// AwaitOr awaits p and returns its value, or fallback should p fail or ctx be
+// cancelled first.
+func AwaitOr[T any](ctx context.Context, p Promise[T], fallback T) T {
+	v, err := p.Await(ctx)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// MustAwait awaits p and returns its value, panicking with the error should p
+// fail or ctx be cancelled first. It is meant for tests and initialization
+// code, where a failure is not expected to be handled.
+func MustAwait[T any](ctx context.Context, p Promise[T]) T {
+	v, err := p.Await(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Peek returns the value of p without blocking. The boolean is true only if p
+// has already settled and it resolved successfully; otherwise the zero value
+// is returned along with false.
+func Peek[T any](p Promise[T]) (T, bool) {
+	if !p.Settled() {
+		var zerov T
+		return zerov, false
+	}
+	v, err := p.Await(context.Background())
+	return v, err == nil
+}
+
+// AwaitChainTimed awaits a, then awaits the promise that next makes from its
+// value, and returns the final value along with how long was spent awaiting
+// each of the two stages. Should the first stage fail, next is not called, and
+// the second duration is zero.
+func AwaitChainTimed[A, B any](ctx context.Context, a Promise[A], next func(A) Promise[B]) (B, time.Duration, time.Duration, error) {
+	var zerov B
+	start := time.Now()
+	av, err := a.Await(ctx)
+	first := time.Since(start)
+	if err != nil {
+		return zerov, first, 0, err
+	}
+	start = time.Now()
+	bv, err := next(av).Await(ctx)
+	return bv, first, time.Since(start), err
+}
@@ -0,0 +1,72 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAwaitOr(t *testing.T) {
+	ctx := context.Background()
+	requireEqual(t, "live", AwaitOr(ctx, Resolve("live"), "fallback"))
+	requireEqual(t, "fallback", AwaitOr(ctx, Reject[string](errors.New("doh!")), "fallback"))
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	requireEqual(t, "fallback", AwaitOr[string](cctx, newCancelProbe[string](), "fallback"))
+}
+
+func TestMustAwait(t *testing.T) {
+	ctx := context.Background()
+	requireEqual(t, 42, MustAwait(ctx, Resolve(42)))
+
+	doh := errors.New("doh!")
+	defer func() {
+		requireEqual(t, any(doh), recover())
+	}()
+	MustAwait(ctx, Reject[int](doh))
+	t.Fatal("expected MustAwait to panic")
+}
+
+func TestPeek(t *testing.T) {
+	v, ok := Peek(Resolve(42))
+	requireEqual(t, true, ok)
+	requireEqual(t, 42, v)
+
+	_, ok = Peek(Reject[int](errors.New("doh!")))
+	requireEqual(t, false, ok)
+
+	d, _ := NewDeferred[int]()
+	_, ok = Peek(d)
+	requireEqual(t, false, ok)
+}
+
+func TestAwaitChainTimed(t *testing.T) {
+	ctx := context.Background()
+	sleepy := func(d time.Duration, v int) Promise[int] {
+		return NewPromise(func() (int, error) {
+			time.Sleep(d)
+			return v, nil
+		})
+	}
+	v, first, second, err := AwaitChainTimed(ctx, sleepy(time.Millisecond*20, 1), func(v int) Promise[string] {
+		return Then(sleepy(time.Millisecond*60, v+1), func(v int) (string, error) {
+			return "got " + strconv.Itoa(v), nil
+		})
+	})
+	requireNoError(t, err)
+	requireEqual(t, "got 2", v)
+	if first < time.Millisecond*20 || second < time.Millisecond*60 || first >= second {
+		t.Fatalf("unexpected stage durations %s and %s", first, second)
+	}
+
+	doh := errors.New("doh!")
+	_, _, second, err = AwaitChainTimed(ctx, Reject[int](doh), func(int) Promise[string] {
+		t.Error("next should not be called when the first stage fails")
+		return nil
+	})
+	requireEqual(t, doh, err)
+	requireEqual(t, time.Duration(0), second)
+}
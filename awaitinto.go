@@ -0,0 +1,17 @@
+package async
+
+import (
+	"context"
+	"io"
+)
+
+// AwaitInto awaits p and, if it succeeds, writes its value to w with encode.
+// Any error from either awaiting or encoding is returned. This streamlines the
+// common pattern of awaiting a value and serializing it into a response.
+func AwaitInto[T any](ctx context.Context, p Promise[T], w io.Writer, encode func(io.Writer, T) error) error {
+	v, err := p.Await(ctx)
+	if err != nil {
+		return err
+	}
+	return encode(w, v)
+}
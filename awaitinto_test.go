@@ -0,0 +1,27 @@
+package async
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestAwaitInto(t *testing.T) {
+	ctx := context.Background()
+	encodeJSON := func(w io.Writer, v map[string]int) error {
+		return json.NewEncoder(w).Encode(v)
+	}
+	var buf bytes.Buffer
+	err := AwaitInto(ctx, Resolve(map[string]int{"answer": 42}), &buf, encodeJSON)
+	requireNoError(t, err)
+	requireEqual(t, "{\"answer\":42}\n", buf.String())
+
+	buf.Reset()
+	doh := errors.New("doh!")
+	err = AwaitInto(ctx, Reject[map[string]int](doh), &buf, encodeJSON)
+	requireEqual(t, doh, err)
+	requireEqual(t, 0, buf.Len())
+}
@@ -0,0 +1,46 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Barrier waits for a group of promises, of any types, to settle. The zero
+// value is ready to use.
+type Barrier struct {
+	mu      sync.Mutex
+	waiters []func()
+	done    Promise[struct{}]
+}
+
+// BarrierAdd registers p with b. Promises may only be added until Wait is
+// first called; adding one afterwards panics.
+func BarrierAdd[T any](b *Barrier, p Promise[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done != nil {
+		panic("async: BarrierAdd called after Barrier.Wait")
+	}
+	b.waiters = append(b.waiters, func() {
+		_, _ = p.Await(context.Background())
+	})
+}
+
+// Wait returns a promise that resolves once every promise registered with the
+// barrier has settled, whether it succeeded or failed. Every call to Wait
+// returns the same promise.
+func (b *Barrier) Wait() Promise[struct{}] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done == nil {
+		waiters := b.waiters
+		b.waiters = nil
+		b.done = NewPromise(func() (struct{}, error) {
+			for _, wait := range waiters {
+				wait()
+			}
+			return struct{}{}, nil
+		})
+	}
+	return b.done
+}
@@ -0,0 +1,31 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBarrier(t *testing.T) {
+	var b Barrier
+	slow := NewPromise(func() (string, error) {
+		time.Sleep(time.Millisecond * 30)
+		return "", errors.New("doh!")
+	})
+	BarrierAdd[int](&b, Resolve(1))
+	BarrierAdd(&b, slow)
+	BarrierAdd(&b, Reject[struct{}](errors.New("doh!")))
+	done := b.Wait()
+	requireEqual(t, false, done.Settled())
+
+	_, err := done.Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, true, slow.Settled())
+	requireEqual(t, done, b.Wait())
+
+	defer func() {
+		requireEqual(t, true, recover() != nil)
+	}()
+	BarrierAdd(&b, Resolve(2))
+}
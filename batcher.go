@@ -0,0 +1,100 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrMissingKey is returned by a Batcher for a key that the batch function
+// left out of its results.
+var ErrMissingKey = errors.New("async: batch result is missing key")
+
+type loadBatch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	pending map[K]*syncPromise[V]
+	timer   *time.Timer
+}
+
+// Batcher coalesces individual loads into batched calls to a backend, in the
+// manner of a DataLoader: keys loaded close together are gathered up and
+// fetched with a single call of the batch function.
+type Batcher[K comparable, V any] struct {
+	fetch   func(context.Context, []K) (map[K]V, error)
+	maxSize int
+	maxWait time.Duration
+
+	mu      sync.Mutex
+	current *loadBatch[K, V]
+}
+
+// NewBatcher creates a Batcher that calls fetch with a batch of distinct keys
+// once either maxSize keys have been loaded, or maxWait has passed since the
+// first key of the batch was loaded.
+func NewBatcher[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error), maxSize int, maxWait time.Duration) *Batcher[K, V] {
+	return &Batcher[K, V]{
+		fetch:   fetch,
+		maxSize: maxSize,
+		maxWait: maxWait,
+	}
+}
+
+// Load adds key to the current batch, and returns a promise that settles once
+// that batch has been fetched. Loading a key that is already in the batch
+// returns the same promise. Should the fetch fail, every promise in the batch
+// is rejected with its error; a key missing from the results is rejected with
+// ErrMissingKey. The batch is fetched with the values, but not the
+// cancellation, of the ctx of its first load, since the batch is shared by
+// many callers; each caller can still give up by cancelling its own await.
+func (b *Batcher[K, V]) Load(ctx context.Context, key K) Promise[V] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lb := b.current
+	if lb == nil {
+		lb = &loadBatch[K, V]{
+			ctx:     context.WithoutCancel(ctx),
+			pending: map[K]*syncPromise[V]{},
+		}
+		lb.timer = time.AfterFunc(b.maxWait, func() {
+			b.mu.Lock()
+			if b.current != lb {
+				b.mu.Unlock()
+				return
+			}
+			b.current = nil
+			b.mu.Unlock()
+			b.run(lb)
+		})
+		b.current = lb
+	}
+	if p, ok := lb.pending[key]; ok {
+		return p
+	}
+	p := newSyncPromise[V]()
+	lb.pending[key] = p
+	lb.keys = append(lb.keys, key)
+	if len(lb.keys) >= b.maxSize {
+		lb.timer.Stop()
+		b.current = nil
+		go b.run(lb)
+	}
+	return p
+}
+
+func (b *Batcher[K, V]) run(lb *loadBatch[K, V]) {
+	values, err := safeCall(func() (map[K]V, error) {
+		return b.fetch(lb.ctx, lb.keys)
+	})
+	for key, p := range lb.pending {
+		var zerov V
+		if err != nil {
+			p.settle(zerov, err)
+		} else if v, ok := values[key]; ok {
+			p.settle(v, nil)
+		} else {
+			p.settle(zerov, ErrMissingKey)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatcher(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var batches [][]int
+	b := NewBatcher(func(_ context.Context, keys []int) (map[int]string, error) {
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+		out := map[int]string{}
+		for _, k := range keys {
+			if k != 4 {
+				out[k] = fmt.Sprint("user", k)
+			}
+		}
+		return out, nil
+	}, 3, time.Millisecond*10)
+
+	first := []Promise[string]{b.Load(ctx, 1), b.Load(ctx, 2), b.Load(ctx, 1), b.Load(ctx, 3)}
+	requireEqual(t, first[0], first[2])
+	v, err := All(ctx, first)
+	requireNoError(t, err)
+	requireEqual(t, []string{"user1", "user2", "user1", "user3"}, v)
+
+	_, err = b.Load(ctx, 4).Await(ctx)
+	requireEqual(t, ErrMissingKey, err)
+	requireEqual(t, [][]int{{1, 2, 3}, {4}}, batches)
+
+	doh := errors.New("doh!")
+	failing := NewBatcher(func(context.Context, []int) (map[int]string, error) {
+		return nil, doh
+	}, 10, time.Millisecond)
+	_, err = failing.Load(ctx, 1).Await(ctx)
+	requireEqual(t, doh, err)
+}
@@ -0,0 +1,44 @@
+package async
+
+import (
+	"context"
+)
+
+// Not returns a promise that resolves with the negation of the value of p.
+// Errors from p are propagated unchanged.
+func Not(p Promise[bool]) Promise[bool] {
+	return Then(p, func(v bool) (bool, error) {
+		return !v, nil
+	})
+}
+
+// And awaits the promises one after the other, in order, and reports whether
+// all of them resolved true. Like the && operator, it stops at the first false
+// value, without awaiting the promises after it. The first error encountered
+// is returned.
+func And(ctx context.Context, ps ...Promise[bool]) (bool, error) {
+	for _, p := range ps {
+		v, err := p.Await(ctx)
+		if err != nil || !v {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Or awaits the promises one after the other, in order, and reports whether
+// any of them resolved true. Like the || operator, it stops at the first true
+// value, without awaiting the promises after it. The first error encountered
+// is returned.
+func Or(ctx context.Context, ps ...Promise[bool]) (bool, error) {
+	for _, p := range ps {
+		v, err := p.Await(ctx)
+		if err != nil {
+			return false, err
+		}
+		if v {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,54 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// unawaited is a promise that fails the test if it is ever awaited.
+type unawaited struct{ t *testing.T }
+
+func (u unawaited) Settled() bool { return false }
+
+func (u unawaited) Await(context.Context) (bool, error) {
+	u.t.Error("promise should not have been awaited")
+	return false, nil
+}
+
+func TestNot(t *testing.T) {
+	ctx := context.Background()
+	v, err := Not(Resolve(true)).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, false, v)
+
+	doh := errors.New("doh!")
+	_, err = Not(Reject[bool](doh)).Await(ctx)
+	requireEqual(t, doh, err)
+}
+
+func TestAnd(t *testing.T) {
+	ctx := context.Background()
+	v, err := And(ctx, Resolve(true), Resolve(false), unawaited{t})
+	requireNoError(t, err)
+	requireEqual(t, false, v)
+
+	v, err = And(ctx, Resolve(true), Resolve(true))
+	requireNoError(t, err)
+	requireEqual(t, true, v)
+
+	doh := errors.New("doh!")
+	_, err = And(ctx, Resolve(true), Reject[bool](doh), unawaited{t})
+	requireEqual(t, doh, err)
+}
+
+func TestOr(t *testing.T) {
+	ctx := context.Background()
+	v, err := Or(ctx, Resolve(false), Resolve(true), unawaited{t})
+	requireNoError(t, err)
+	requireEqual(t, true, v)
+
+	v, err = Or(ctx, Resolve(false), Resolve(false))
+	requireNoError(t, err)
+	requireEqual(t, false, v)
+}
@@ -0,0 +1,45 @@
+package async
+
+import (
+	"context"
+)
+
+// AllByKey awaits all of the promises like All, and returns their values keyed
+// by keyFn. Should more than one value produce the same key, the value from the
+// promise that comes last in input order wins, regardless of the order the
+// promises settled in.
+func AllByKey[T any, K comparable](ctx context.Context, promises []Promise[T], keyFn func(T) K) (map[K]T, error) {
+	values, err := All(ctx, promises)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[K]T, len(values))
+	for _, v := range values {
+		out[keyFn(v)] = v
+	}
+	return out, nil
+}
+
+// ResolveKeys calls fn concurrently for every distinct key in keys, so that a
+// key given more than once is only resolved once, and returns the values keyed
+// by their key. The first error cancels the context given to the calls still
+// in flight, and is returned. This suits loading a batch of records by id.
+func ResolveKeys[K comparable, V any](ctx context.Context, keys []K, fn func(context.Context, K) (V, error)) (map[K]V, error) {
+	seen := make(map[K]struct{}, len(keys))
+	unique := make([]K, 0, len(keys))
+	for _, k := range keys {
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			unique = append(unique, k)
+		}
+	}
+	values, err := mapLimit(ctx, unique, 0, fn)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[K]V, len(unique))
+	for i, k := range unique {
+		out[k] = values[i]
+	}
+	return out, nil
+}
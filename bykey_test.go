@@ -0,0 +1,64 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAllByKey(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+	ctx := context.Background()
+	byID := func(u user) int { return u.ID }
+	users, err := AllByKey(ctx, []Promise[user]{
+		Resolve(user{1, "alice"}),
+		Resolve(user{2, "bob"}),
+	}, byID)
+	requireNoError(t, err)
+	requireEqual(t, map[int]user{1: {1, "alice"}, 2: {2, "bob"}}, users)
+
+	users, err = AllByKey(ctx, []Promise[user]{
+		Resolve(user{1, "alice"}),
+		NewPromise(func() (user, error) {
+			time.Sleep(time.Millisecond * 10)
+			return user{1, "alice (stale)"}, nil
+		}),
+		Resolve(user{1, "alice (latest)"}),
+	}, byID)
+	requireNoError(t, err)
+	requireEqual(t, map[int]user{1: {1, "alice (latest)"}}, users)
+
+	_, err = AllByKey(ctx, []Promise[user]{Reject[user](errors.New("doh!"))}, byID)
+	requireError(t, err)
+}
+
+func TestResolveKeys(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	calls := map[int]int{}
+	load := func(_ context.Context, id int) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[id]++
+		return "user " + strconv.Itoa(id), nil
+	}
+	v, err := ResolveKeys(ctx, []int{1, 2, 1, 3, 2}, load)
+	requireNoError(t, err)
+	requireEqual(t, map[int]string{1: "user 1", 2: "user 2", 3: "user 3"}, v)
+	requireEqual(t, map[int]int{1: 1, 2: 1, 3: 1}, calls)
+
+	doh := errors.New("doh!")
+	_, err = ResolveKeys(ctx, []int{1, 2}, func(_ context.Context, id int) (string, error) {
+		if id == 2 {
+			return "", doh
+		}
+		return "", nil
+	})
+	requireEqual(t, doh, err)
+}
@@ -0,0 +1,75 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PromiseCache memoizes promises by key: while a promise for a key is in
+// flight, or its result is still fresh, every call to Get for that key shares
+// it. This combines singleflight with a cache, as API clients often need. The
+// zero value is ready to use, and caches values forever and errors not at all.
+type PromiseCache[K comparable, V any] struct {
+	ttl      time.Duration
+	errorTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[K]*cacheEntry[V]
+}
+
+type cacheEntry[V any] struct {
+	p *syncPromise[V]
+
+	// expires is when a settled entry goes stale, or zero if it never does.
+	expires time.Time
+}
+
+// NewPromiseCache creates a PromiseCache that keeps a value for ttl after it
+// resolves, and an error for errorTTL after it rejects. A ttl that is not
+// positive keeps values forever, and an errorTTL that is not positive does not
+// cache errors at all, so that the next call to Get tries again.
+func NewPromiseCache[K comparable, V any](ttl, errorTTL time.Duration) *PromiseCache[K, V] {
+	return &PromiseCache[K, V]{ttl: ttl, errorTTL: errorTTL}
+}
+
+// Get returns the in-flight or still fresh promise for key, or if there is
+// none, runs fn in a new goroutine and returns a promise for its result. fn is
+// given ctx with its cancellation removed, since other callers may come to
+// share the result.
+func (c *PromiseCache[K, V]) Get(ctx context.Context, key K, fn func(context.Context) (V, error)) Promise[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok && (!e.p.Settled() || e.expires.IsZero() || time.Now().Before(e.expires)) {
+		return e.p
+	}
+	if c.entries == nil {
+		c.entries = make(map[K]*cacheEntry[V])
+	}
+	e := &cacheEntry[V]{p: newSyncPromise[V]()}
+	c.entries[key] = e
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		v, err := safeCall(func() (V, error) { return fn(ctx) })
+		c.mu.Lock()
+		switch {
+		case err == nil && c.ttl > 0:
+			e.expires = time.Now().Add(c.ttl)
+		case err != nil && c.errorTTL > 0:
+			e.expires = time.Now().Add(c.errorTTL)
+		case err != nil && c.entries[key] == e:
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		e.p.settle(v, err)
+	}()
+	return e.p
+}
+
+// Forget drops the promise for key, if there is one, so that the next call to
+// Get starts afresh. Callers already holding the promise are unaffected.
+func (c *PromiseCache[K, V]) Forget(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
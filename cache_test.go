@@ -0,0 +1,76 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPromiseCache(t *testing.T) {
+	ctx := context.Background()
+	var c PromiseCache[string, int32]
+	var calls atomic.Int32
+	fn := func(context.Context) (int32, error) {
+		time.Sleep(time.Millisecond * 10)
+		return calls.Add(1), nil
+	}
+	var wg sync.WaitGroup
+	results := make([]Settled[int32], 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.Get(ctx, "key", fn).Await(ctx)
+			results[i] = Settled[int32]{Value: v, Err: err}
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range results {
+		requireEqual(t, Settled[int32]{Value: 1}, r)
+	}
+	v, err := c.Get(ctx, "key", fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int32(1), v)
+
+	c.Forget("key")
+	v, err = c.Get(ctx, "key", fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int32(2), v)
+}
+
+func TestPromiseCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	var calls atomic.Int32
+	c := NewPromiseCache[string, int32](time.Millisecond*20, time.Millisecond*20)
+	fn := func(context.Context) (int32, error) {
+		return calls.Add(1), nil
+	}
+	v, _ := c.Get(ctx, "key", fn).Await(ctx)
+	requireEqual(t, int32(1), v)
+	v, _ = c.Get(ctx, "key", fn).Await(ctx)
+	requireEqual(t, int32(1), v)
+	time.Sleep(time.Millisecond * 30)
+	v, _ = c.Get(ctx, "key", fn).Await(ctx)
+	requireEqual(t, int32(2), v)
+
+	failing := func(context.Context) (int32, error) {
+		calls.Add(1)
+		return 0, doh
+	}
+	calls.Store(0)
+	_, err := c.Get(ctx, "failing", failing).Await(ctx)
+	requireEqual(t, doh, err)
+	_, err = c.Get(ctx, "failing", failing).Await(ctx)
+	requireEqual(t, doh, err)
+	requireEqual(t, int32(1), calls.Load())
+
+	var uncached PromiseCache[string, int32]
+	calls.Store(0)
+	uncached.Get(ctx, "failing", failing).Await(ctx)
+	uncached.Get(ctx, "failing", failing).Await(ctx)
+	requireEqual(t, int32(2), calls.Load())
+}
@@ -0,0 +1,10 @@
+package async
+
+// FromCallback bridges a callback-based API into a promise. register is called
+// immediately with a callback that settles the returned promise; the first
+// invocation of the callback wins, and any later invocations are ignored.
+func FromCallback[T any](register func(cb func(T, error))) Promise[T] {
+	c := newSyncPromise[T]()
+	register(c.settle)
+	return c
+}
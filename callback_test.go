@@ -0,0 +1,21 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFromCallback(t *testing.T) {
+	var cb func(string, error)
+	promise := FromCallback(func(fn func(string, error)) {
+		cb = fn
+	})
+	requireEqual(t, false, promise.Settled())
+	cb("first", nil)
+	cb("second", errors.New("ignored"))
+
+	v, err := promise.Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, "first", v)
+}
@@ -0,0 +1,50 @@
+package async
+
+import (
+	"context"
+	"fmt"
+)
+
+// CancelablePromise is a promise whose underlying work may be abandoned by the
+// holder of the promise.
+type CancelablePromise[T any] interface {
+	Promise[T]
+
+	// Close cancels the context given to the work function and immediately
+	// rejects the promise (if it has not already settled) with an error that
+	// satisfies errors.Is for both context.Canceled and reason. The reason
+	// lets awaiting callers tell why the work was cancelled; it may be nil.
+	Close(reason error)
+}
+
+type cancelablePromise[T any] struct {
+	*syncPromise[T]
+	cancel context.CancelCauseFunc
+}
+
+func (c *cancelablePromise[T]) Close(reason error) {
+	err := context.Canceled
+	if reason != nil {
+		err = fmt.Errorf("%w: %w", context.Canceled, reason)
+	}
+	c.cancel(err)
+	var zerov T
+	c.settle(zerov, err)
+}
+
+// NewCancelablePromise runs fn in a new goroutine, like NewPromise, and
+// returns a promise that may be cancelled with Close. The context given to fn
+// is derived from ctx and is cancelled when Close is called, with the error
+// passed to Close available from context.Cause.
+func NewCancelablePromise[T any](ctx context.Context, fn func(context.Context) (T, error)) CancelablePromise[T] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	c := &cancelablePromise[T]{
+		syncPromise: newSyncPromise[T](),
+		cancel:      cancel,
+	}
+	go func() {
+		defer cancel(nil)
+		c.settle(safeCall(func() (T, error) { return fn(ctx) }))
+	}()
+	return c
+}
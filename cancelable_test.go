@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewCancelablePromise(t *testing.T) {
+	ctx := context.Background()
+	observed := make(chan error, 1)
+	promise := NewCancelablePromise(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		observed <- context.Cause(ctx)
+		return 0, ctx.Err()
+	})
+	superseded := errors.New("superseded")
+	promise.Close(superseded)
+
+	_, err := promise.Await(ctx)
+	requireEqual(t, true, errors.Is(err, context.Canceled))
+	requireEqual(t, true, errors.Is(err, superseded))
+	select {
+	case cause := <-observed:
+		requireEqual(t, true, errors.Is(cause, superseded))
+	case <-time.After(time.Second):
+		t.Fatal("work function did not observe cancellation")
+	}
+
+	promise = NewCancelablePromise(ctx, func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	_, err = promise.Await(ctx)
+	requireNoError(t, err)
+	promise.Close(superseded)
+	v, err := promise.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 42, v)
+}
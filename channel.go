@@ -0,0 +1,100 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrChannelClosed is returned by promises derived from channels when the
+// channel is closed before it ever delivers a value.
+var ErrChannelClosed = errors.New("async: channel closed without a value")
+
+// FromChannelFirst resolves with the first value received from ch. If ch is
+// closed without sending a value, the promise is rejected with
+// ErrChannelClosed, and should ctx be cancelled before either happens, with the
+// context error. Nothing more is received from ch once the promise settles.
+func FromChannelFirst[T any](ctx context.Context, ch <-chan T) Promise[T] {
+	return NewPromise(func() (T, error) {
+		select {
+		case <-ctx.Done():
+			var zerov T
+			return zerov, ctx.Err()
+		case v, ok := <-ch:
+			if !ok {
+				return v, ErrChannelClosed
+			}
+			return v, nil
+		}
+	})
+}
+
+// FromChannelLast drains ch until it is closed and resolves with the last
+// value that was received. If ch is closed without ever sending a value, the
+// promise is rejected with ErrChannelClosed. Should ctx be cancelled before ch
+// is closed, the promise resolves with the last value seen so far, or rejects
+// with the context error if nothing has been received yet.
+func FromChannelLast[T any](ctx context.Context, ch <-chan T) Promise[T] {
+	return NewPromise(func() (T, error) {
+		var (
+			last T
+			seen bool
+		)
+		for {
+			select {
+			case <-ctx.Done():
+				if seen {
+					return last, nil
+				}
+				return last, ctx.Err()
+			case v, ok := <-ch:
+				if !ok {
+					if !seen {
+						return last, ErrChannelClosed
+					}
+					return last, nil
+				}
+				last, seen = v, true
+			}
+		}
+	})
+}
+
+// FromChannel drains ch until it is closed, and resolves with every value that
+// was received, in order. If ctx is cancelled before ch is closed, the promise
+// rejects with the context error.
+func FromChannel[T any](ctx context.Context, ch <-chan T) Promise[[]T] {
+	return NewPromise(func() ([]T, error) {
+		var out []T
+		for {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case v, ok := <-ch:
+				if !ok {
+					return out, nil
+				}
+				out = append(out, v)
+			}
+		}
+	})
+}
+
+// ToChannel delivers the outcome of each of the promises on the returned
+// channel as soon as it settles, in the order that they settle, and closes the
+// channel once all of them have. This lets promises, even a single one, take
+// part in a select statement. The channel is buffered to fit every promise, so
+// a consumer that stops receiving early does not leak goroutines.
+// If ctx is cancelled, promises that are still pending are delivered with the
+// context error.
+func ToChannel[T any](ctx context.Context, promises []Promise[T]) <-chan Settled[T] {
+	out := make(chan Settled[T], len(promises))
+	results := awaitEach(ctx, promises)
+	go func() {
+		defer close(out)
+		for range promises {
+			r := <-results
+			out <- Settled[T]{Value: r.v, Err: r.err}
+		}
+	}()
+	return out
+}
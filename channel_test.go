@@ -0,0 +1,83 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFromChannelLast(t *testing.T) {
+	ctx := context.Background()
+	ch := make(chan int)
+	go func() {
+		for i := 1; i <= 3; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+	v, err := FromChannelLast(ctx, ch).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 3, v)
+
+	empty := make(chan int)
+	close(empty)
+	_, err = FromChannelLast(ctx, empty).Await(ctx)
+	requireEqual(t, ErrChannelClosed, err)
+}
+
+func TestFromChannel(t *testing.T) {
+	ctx := context.Background()
+	ch := make(chan string)
+	go func() {
+		for _, page := range []string{"page 1", "page 2", "page 3"} {
+			ch <- page
+		}
+		close(ch)
+	}()
+	v, err := FromChannel(ctx, ch).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, []string{"page 1", "page 2", "page 3"}, v)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = FromChannel(cctx, make(chan string)).Await(ctx)
+	requireEqual(t, context.Canceled, err)
+}
+
+func TestToChannel(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	var got []Settled[int]
+	for s := range ToChannel(ctx, []Promise[int]{
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 1, nil
+		}),
+		Reject[int](doh),
+	}) {
+		got = append(got, s)
+	}
+	requireEqual(t, []Settled[int]{{Err: doh}, {Value: 1}}, got)
+}
+
+func TestFromChannelFirst(t *testing.T) {
+	ctx := context.Background()
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	v, err := FromChannelFirst(ctx, ch).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+	requireEqual(t, 1, len(ch))
+
+	closed := make(chan int)
+	close(closed)
+	_, err = FromChannelFirst(ctx, closed).Await(ctx)
+	requireEqual(t, ErrChannelClosed, err)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = FromChannelFirst(cctx, make(chan int)).Await(ctx)
+	requireEqual(t, context.Canceled, err)
+}
@@ -0,0 +1,47 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrDeadline is returned by CheapestWithin when no promise settles within the
+// time allowed.
+var ErrDeadline = errors.New("async: no promise settled before the deadline")
+
+// CheapestWithin collects the successful results of every promise that settles
+// within d, and returns the one for which cost is lowest. If no promise settles
+// in time, ErrDeadline is returned. If some do, but every one of them failed,
+// their errors are returned joined together.
+func CheapestWithin[T any](ctx context.Context, d time.Duration, cost func(T) float64, promises []Promise[T]) (T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, d)
+	defer cancel()
+	var (
+		best     T
+		bestCost float64
+		found    bool
+		errs     []error
+	)
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			if ctx.Err() == nil || !isContextError(r.err) {
+				errs = append(errs, r.err)
+			}
+			continue
+		}
+		if c := cost(r.v); !found || c < bestCost {
+			best, bestCost, found = r.v, c, true
+		}
+	}
+	if found {
+		return best, nil
+	}
+	if len(errs) > 0 {
+		return best, errors.Join(errs...)
+	}
+	return best, ErrDeadline
+}
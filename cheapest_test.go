@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheapestWithin(t *testing.T) {
+	ctx := context.Background()
+	identity := func(v int) float64 { return float64(v) }
+	v, err := CheapestWithin(ctx, time.Millisecond*50, identity, []Promise[int]{
+		Resolve(30),
+		Resolve(10),
+		Reject[int](errors.New("doh!")),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 10)
+			return 20, nil
+		}),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil // cheapest, but far too late
+		}),
+	})
+	requireNoError(t, err)
+	requireEqual(t, 10, v)
+
+	_, err = CheapestWithin(ctx, time.Millisecond*10, identity, []Promise[int]{
+		newCancelProbe[int](),
+	})
+	requireEqual(t, ErrDeadline, err)
+
+	_, err = CheapestWithin(ctx, time.Millisecond*10, identity, []Promise[int]{
+		Reject[int](errors.New("doh!")),
+		newCancelProbe[int](),
+	})
+	requireError(t, err)
+	requireEqual(t, false, errors.Is(err, ErrDeadline))
+}
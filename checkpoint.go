@@ -0,0 +1,22 @@
+package async
+
+import (
+	"context"
+)
+
+// Checkpoint returns the error of ctx if it has been cancelled, and nil
+// otherwise. Long-running work functions can call it at convenient points to
+// stop promptly once their context is cancelled.
+func Checkpoint(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// NewYieldingPromise is like NewPromiseContext, except that fn is also given
+// yield, which is Checkpoint bound to the context of the promise. This makes
+// it easy for fn to check for cancellation at known points, for example with
+// each iteration of a loop.
+func NewYieldingPromise[T any](ctx context.Context, fn func(ctx context.Context, yield func() error) (T, error)) Promise[T] {
+	return NewPromiseContext(ctx, func(ctx context.Context) (T, error) {
+		return fn(ctx, func() error { return Checkpoint(ctx) })
+	})
+}
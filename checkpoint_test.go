@@ -0,0 +1,32 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewYieldingPromise(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	exited := make(chan error, 1)
+	promise := NewYieldingPromise(ctx, func(ctx context.Context, yield func() error) (int, error) {
+		for i := 0; ; i++ {
+			if err := yield(); err != nil {
+				exited <- err
+				return i, err
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+	time.Sleep(time.Millisecond * 10)
+	cancel()
+	_, err := promise.Await(context.Background())
+	requireEqual(t, context.Canceled, err)
+	select {
+	case err := <-exited:
+		requireEqual(t, context.Canceled, err)
+	case <-time.After(time.Second):
+		t.Fatal("work function did not exit after cancellation")
+	}
+	requireNoError(t, Checkpoint(context.Background()))
+}
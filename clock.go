@@ -0,0 +1,136 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock is the source of time for the functions in this package that wait,
+// like WithTimeout, Retry and Debounce. Swapping in a fake Clock with SetClock
+// lets tests drive those functions without real sleeps; the asynctest package
+// provides one.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer returns a Timer that delivers the time on its channel once d
+	// has passed.
+	NewTimer(d time.Duration) Timer
+
+	// AfterFunc returns a Timer that calls f on a goroutine of its own once d
+	// has passed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single event scheduled on a Clock.
+type Timer interface {
+	// C returns the channel the time is delivered on. Timers made by
+	// AfterFunc have no channel, and return nil.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing, reporting false if it already had
+	// or had already been stopped.
+	Stop() bool
+}
+
+// RealClock is the Clock backed by the time package. It is the Clock in use
+// unless SetClock says otherwise.
+type RealClock struct{}
+
+// Now returns time.Now().
+func (RealClock) Now() time.Time { return time.Now() }
+
+// NewTimer wraps time.NewTimer.
+func (RealClock) NewTimer(d time.Duration) Timer { return realTimer{t: time.NewTimer(d)} }
+
+// AfterFunc wraps time.AfterFunc.
+func (RealClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{t: time.AfterFunc(d, f)}
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (r realTimer) C() <-chan time.Time { return r.t.C }
+
+func (r realTimer) Stop() bool { return r.t.Stop() }
+
+var clock atomic.Pointer[Clock]
+
+// SetClock sets the Clock used by this package from then on. A nil c restores
+// the RealClock. It is meant for tests, and should not be called while
+// anything is waiting on the previous Clock.
+func SetClock(c Clock) {
+	if c == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&c)
+}
+
+// now returns the current time of the Clock in use.
+func now() time.Time {
+	return currentClock().Now()
+}
+
+func currentClock() Clock {
+	if c := clock.Load(); c != nil {
+		return *c
+	}
+	return RealClock{}
+}
+
+// withClockTimeout is context.WithTimeout, timed by the Clock in use.
+func withClockTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	c := currentClock()
+	if _, ok := c.(RealClock); ok {
+		return context.WithTimeout(ctx, d)
+	}
+	tctx := &clockTimeoutCtx{
+		Context:  ctx,
+		deadline: c.Now().Add(d),
+		done:     make(chan struct{}),
+	}
+	stopParent := context.AfterFunc(ctx, func() { tctx.cancel(ctx.Err()) })
+	timer := c.AfterFunc(d, func() { tctx.cancel(context.DeadlineExceeded) })
+	return tctx, func() {
+		stopParent()
+		timer.Stop()
+		tctx.cancel(context.Canceled)
+	}
+}
+
+// clockTimeoutCtx is the context made by withClockTimeout under a Clock other
+// than the RealClock. Like the context made by context.WithTimeout, it reports
+// context.DeadlineExceeded from Err once its deadline passes, so that code
+// under test sees the same errors that it would in production.
+type clockTimeoutCtx struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+func (c *clockTimeoutCtx) Deadline() (time.Time, bool) { return c.deadline, true }
+
+func (c *clockTimeoutCtx) Done() <-chan struct{} { return c.done }
+
+func (c *clockTimeoutCtx) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *clockTimeoutCtx) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+		close(c.done)
+	}
+}
@@ -0,0 +1,53 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// CoalesceEager awaits all of the promises at once, but returns the first
+// success in input order: the value of promises[0] is preferred, and the value
+// of a later promise is only returned once every promise before it has failed.
+// This makes it possible to prefer a primary source and fall back on the
+// others, without waiting for the primary to fail before starting on them. If
+// all of the promises fail, the joined errors are returned.
+func CoalesceEager[T any](ctx context.Context, promises []Promise[T]) (T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	results := awaitEach(ctx, promises)
+	settled := make([]*outcome[T], len(promises))
+	errs := make([]error, 0, len(promises))
+	next := 0
+	for range promises {
+		r := <-results
+		settled[r.index] = &r
+		for next < len(settled) && settled[next] != nil {
+			if settled[next].err == nil {
+				return settled[next].v, nil
+			}
+			errs = append(errs, settled[next].err)
+			next++
+		}
+	}
+	var zerov T
+	return zerov, errors.Join(errs...)
+}
+
+// Coalesce2 awaits both a and b, and returns the value of a unless isEmpty
+// reports that it is empty, in which case the value of b is returned; failing
+// that, the zero value is returned. This is like SQL's COALESCE over two
+// asynchronous sources. An error from either promise short-circuits.
+func Coalesce2[T any](ctx context.Context, a, b Promise[T], isEmpty func(T) bool) (T, error) {
+	values, err := All(ctx, []Promise[T]{a, b})
+	var zerov T
+	if err != nil {
+		return zerov, err
+	}
+	for _, v := range values {
+		if !isEmpty(v) {
+			return v, nil
+		}
+	}
+	return zerov, nil
+}
@@ -0,0 +1,60 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCoalesceEager(t *testing.T) {
+	ctx := context.Background()
+	v, err := CoalesceEager(ctx, []Promise[string]{
+		NewPromise(func() (string, error) {
+			time.Sleep(time.Millisecond * 50)
+			return "primary", nil
+		}),
+		NewPromise(func() (string, error) {
+			time.Sleep(time.Millisecond * 10)
+			return "", errors.New("secondary down")
+		}),
+		Resolve("tertiary"),
+	})
+	requireNoError(t, err)
+	requireEqual(t, "primary", v)
+
+	v, err = CoalesceEager(ctx, []Promise[string]{
+		NewPromise(func() (string, error) {
+			time.Sleep(time.Millisecond * 20)
+			return "", errors.New("primary down")
+		}),
+		Resolve("secondary"),
+	})
+	requireNoError(t, err)
+	requireEqual(t, "secondary", v)
+
+	_, err = CoalesceEager(ctx, []Promise[string]{
+		Reject[string](errors.New("a")),
+		Reject[string](errors.New("b")),
+	})
+	requireError(t, err)
+}
+
+func TestCoalesce2(t *testing.T) {
+	ctx := context.Background()
+	isEmpty := func(s string) bool { return s == "" }
+	v, err := Coalesce2(ctx, Resolve("a"), Resolve("b"), isEmpty)
+	requireNoError(t, err)
+	requireEqual(t, "a", v)
+
+	v, err = Coalesce2(ctx, Resolve(""), Resolve("b"), isEmpty)
+	requireNoError(t, err)
+	requireEqual(t, "b", v)
+
+	v, err = Coalesce2(ctx, Resolve(""), Resolve(""), isEmpty)
+	requireNoError(t, err)
+	requireEqual(t, "", v)
+
+	_, err = Coalesce2(ctx, Resolve("a"), Reject[string](errors.New("doh!")), isEmpty)
+	requireError(t, err)
+}
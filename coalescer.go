@@ -0,0 +1,22 @@
+package async
+
+// Coalescer deduplicates concurrent work by key: while work for a key is in
+// flight, every call to Do with that key shares the same execution and the
+// same promise. Results are not cached; once the promise settles the key is
+// forgotten, and the next call to Do starts new work. The zero value is ready
+// to use. Coalescer is a Group keyed by string; use a Group directly for other
+// key types, or to cache results.
+type Coalescer[T any] struct {
+	g Group[string, T]
+}
+
+// NewCoalescer creates a new Coalescer.
+func NewCoalescer[T any]() *Coalescer[T] {
+	return &Coalescer[T]{}
+}
+
+// Do returns the in-flight promise for key, or if there is none, runs fn in a
+// new goroutine and returns a promise for its result.
+func (c *Coalescer[T]) Do(key string, fn func() (T, error)) Promise[T] {
+	return c.g.Do(key, fn)
+}
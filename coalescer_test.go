@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescer(t *testing.T) {
+	ctx := context.Background()
+	c := NewCoalescer[int]()
+	var calls atomic.Int32
+	fn := func() (int, error) {
+		time.Sleep(time.Millisecond * 20)
+		return int(calls.Add(1)), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]Settled[int], 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.Do("key", fn).Await(ctx)
+			results[i] = Settled[int]{Value: v, Err: err}
+		}(i)
+	}
+	wg.Wait()
+	requireEqual(t, int32(1), calls.Load())
+	for _, r := range results {
+		requireNoError(t, r.Err)
+		requireEqual(t, 1, r.Value)
+	}
+
+	v, err := c.Do("key", fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 2, v)
+}
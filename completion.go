@@ -0,0 +1,64 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+// CompletionResult is the value of a single promise awaited by
+// AllByCompletion, along with where it was in the input and when it settled.
+type CompletionResult[T any] struct {
+	Index int
+	Value T
+
+	// SettledAt is when AllByCompletion received the result, which is
+	// shortly after the promise settled, not the moment that it did.
+	// Promises that had already settled before AllByCompletion was called
+	// are all stamped with roughly the time of the call.
+	SettledAt time.Time
+}
+
+// AllByCompletion awaits all of the promises like All, but returns the results
+// ordered by the time each promise settled rather than by input order. This
+// gives a timeline of how a fan-out unfolded. The first error short-circuits,
+// cancelling the remaining awaits.
+func AllByCompletion[T any](ctx context.Context, promises []Promise[T]) ([]CompletionResult[T], error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	out := make([]CompletionResult[T], 0, len(promises))
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		out = append(out, CompletionResult[T]{
+			Index:     r.index,
+			Value:     r.v,
+			SettledAt: time.Now(),
+		})
+	}
+	return out, nil
+}
+
+// AllWithOrder awaits all of the promises like All, returning the values in
+// input order, along with the indices of the promises in the order that they
+// settled. The first error short-circuits, cancelling the remaining awaits.
+func AllWithOrder[T any](ctx context.Context, promises []Promise[T]) (values []T, completionOrder []int, err error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	values = make([]T, len(promises))
+	completionOrder = make([]int, 0, len(promises))
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			return nil, nil, r.err
+		}
+		values[r.index] = r.v
+		completionOrder = append(completionOrder, r.index)
+	}
+	return values, completionOrder, nil
+}
@@ -0,0 +1,60 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAllByCompletion(t *testing.T) {
+	sleepy := func(d time.Duration, v string) Promise[string] {
+		return NewPromise(func() (string, error) {
+			time.Sleep(d)
+			return v, nil
+		})
+	}
+	ctx := context.Background()
+	results, err := AllByCompletion(ctx, []Promise[string]{
+		sleepy(time.Millisecond*60, "slow"),
+		sleepy(time.Millisecond*0, "fast"),
+		sleepy(time.Millisecond*30, "medium"),
+	})
+	requireNoError(t, err)
+	requireEqual(t, 3, len(results))
+	values := []string{}
+	indices := []int{}
+	for i, r := range results {
+		values = append(values, r.Value)
+		indices = append(indices, r.Index)
+		if i > 0 && r.SettledAt.Before(results[i-1].SettledAt) {
+			t.Fatalf("results not ordered by completion time: %v", results)
+		}
+	}
+	requireEqual(t, []string{"fast", "medium", "slow"}, values)
+	requireEqual(t, []int{1, 2, 0}, indices)
+
+	_, err = AllByCompletion(ctx, []Promise[string]{Reject[string](errors.New("doh!"))})
+	requireError(t, err)
+}
+
+func TestAllWithOrder(t *testing.T) {
+	sleepy := func(d time.Duration, v int) Promise[int] {
+		return NewPromise(func() (int, error) {
+			time.Sleep(d)
+			return v, nil
+		})
+	}
+	ctx := context.Background()
+	values, order, err := AllWithOrder(ctx, []Promise[int]{
+		sleepy(time.Millisecond*60, 10),
+		sleepy(time.Millisecond*0, 20),
+		sleepy(time.Millisecond*30, 30),
+	})
+	requireNoError(t, err)
+	requireEqual(t, []int{10, 20, 30}, values)
+	requireEqual(t, []int{1, 2, 0}, order)
+
+	_, _, err = AllWithOrder(ctx, []Promise[int]{Resolve(1), Reject[int](errors.New("doh!"))})
+	requireError(t, err)
+}
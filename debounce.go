@@ -0,0 +1,70 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Debounce returns a function that coalesces bursts of calls into a single
+// run of fn. Each call pushes the run back until d has passed without another
+// call, at which point fn runs once, with the context of the last call, and
+// every call in the burst shares the promise for its result. Calls made after
+// that run has started begin a new burst.
+func Debounce[T any](d time.Duration, fn func(context.Context) (T, error)) func(context.Context) Promise[T] {
+	var (
+		mu       sync.Mutex
+		pending  *syncPromise[T]
+		last     context.Context
+		deadline time.Time
+		fire     func()
+	)
+	fire = func() {
+		mu.Lock()
+		if wait := deadline.Sub(now()); wait > 0 {
+			currentClock().AfterFunc(wait, fire)
+			mu.Unlock()
+			return
+		}
+		c, ctx := pending, last
+		pending, last = nil, nil
+		mu.Unlock()
+		c.settle(safeCall(func() (T, error) { return fn(ctx) }))
+	}
+	return func(ctx context.Context) Promise[T] {
+		mu.Lock()
+		defer mu.Unlock()
+		last = ctx
+		deadline = now().Add(d)
+		if pending == nil {
+			pending = newSyncPromise[T]()
+			currentClock().AfterFunc(d, fire)
+		}
+		return pending
+	}
+}
+
+// Throttle returns a function that runs fn at most once per d. The first call
+// runs fn straight away, with its context, and every call within d of that
+// shares the promise for its result. The first call after d has passed runs
+// fn again.
+func Throttle[T any](d time.Duration, fn func(context.Context) (T, error)) func(context.Context) Promise[T] {
+	var (
+		mu      sync.Mutex
+		current Promise[T]
+		started time.Time
+	)
+	return func(ctx context.Context) Promise[T] {
+		mu.Lock()
+		defer mu.Unlock()
+		if current != nil && now().Sub(started) < d {
+			return current
+		}
+		c := newSyncPromise[T]()
+		current, started = c, now()
+		launch(func() {
+			c.settle(safeCall(func() (T, error) { return fn(ctx) }))
+		})
+		return c
+	}
+}
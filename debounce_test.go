@@ -0,0 +1,52 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type debounceKey struct{}
+
+func TestDebounce(t *testing.T) {
+	var runs atomic.Int32
+	lookup := Debounce(time.Millisecond*20, func(ctx context.Context) (string, error) {
+		runs.Add(1)
+		return ctx.Value(debounceKey{}).(string), nil
+	})
+
+	var promises []Promise[string]
+	for _, q := range []string{"a", "as", "asy", "async"} {
+		promises = append(promises, lookup(context.WithValue(context.Background(), debounceKey{}, q)))
+		time.Sleep(time.Millisecond * 5)
+	}
+	v, err := All(context.Background(), promises)
+	requireNoError(t, err)
+	requireEqual(t, []string{"async", "async", "async", "async"}, v)
+	requireEqual(t, int32(1), runs.Load())
+
+	v2, err := lookup(context.WithValue(context.Background(), debounceKey{}, "go")).Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, "go", v2)
+	requireEqual(t, int32(2), runs.Load())
+}
+
+func TestThrottle(t *testing.T) {
+	ctx := context.Background()
+	var runs atomic.Int32
+	refresh := Throttle(time.Millisecond*30, func(context.Context) (int32, error) {
+		return runs.Add(1), nil
+	})
+
+	a, b := refresh(ctx), refresh(ctx)
+	requireEqual(t, a, b)
+	v, err := a.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int32(1), v)
+
+	time.Sleep(time.Millisecond * 40)
+	v, err = refresh(ctx).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int32(2), v)
+}
@@ -0,0 +1,54 @@
+package async
+
+import (
+	"fmt"
+)
+
+// Resolver settles a promise from the outside. Only the first call to either
+// method has any effect.
+type Resolver[T any] interface {
+	// Resolve settles the promise with a value.
+	Resolve(T)
+
+	// Reject settles the promise with an error.
+	Reject(error)
+}
+
+type deferredResolver[T any] struct {
+	p *syncPromise[T]
+}
+
+func (d deferredResolver[T]) Resolve(v T) { d.p.settle(v, nil) }
+
+func (d deferredResolver[T]) Reject(err error) {
+	var zerov T
+	d.p.settle(zerov, err)
+}
+
+// NewDeferred creates a pending promise along with the Resolver that settles
+// it. This can be used to adapt sources of asynchronous results that are not
+// functions, like callbacks or channel messages, into promises.
+func NewDeferred[T any]() (Promise[T], Resolver[T]) {
+	p := newSyncPromise[T]()
+	return p, deferredResolver[T]{p: p}
+}
+
+// ResolveAll resolves each of the resolvers with the value at the same
+// position in values. If the lengths of the two slices differ, an error is
+// returned and none of the resolvers are settled.
+func ResolveAll[T any](resolvers []Resolver[T], values []T) error {
+	if len(resolvers) != len(values) {
+		return fmt.Errorf("async: cannot resolve %d resolvers with %d values", len(resolvers), len(values))
+	}
+	for i, r := range resolvers {
+		r.Resolve(values[i])
+	}
+	return nil
+}
+
+// RejectAll rejects each of the resolvers with err.
+func RejectAll[T any](resolvers []Resolver[T], err error) {
+	for _, r := range resolvers {
+		r.Reject(err)
+	}
+}
@@ -0,0 +1,56 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewDeferred(t *testing.T) {
+	ctx := context.Background()
+	promise, resolver := NewDeferred[int]()
+	requireEqual(t, false, promise.Settled())
+	resolver.Resolve(1)
+	resolver.Reject(errors.New("too late"))
+	v, err := promise.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+}
+
+func TestResolveAll(t *testing.T) {
+	ctx := context.Background()
+	promises := make([]Promise[string], 3)
+	resolvers := make([]Resolver[string], 3)
+	for i := range promises {
+		promises[i], resolvers[i] = NewDeferred[string]()
+	}
+	requireError(t, ResolveAll(resolvers, []string{"a"}))
+	requireEqual(t, false, promises[0].Settled())
+
+	requireNoError(t, ResolveAll(resolvers, []string{"a", "b", "c"}))
+	v, err := All(ctx, promises)
+	requireNoError(t, err)
+	requireEqual(t, []string{"a", "b", "c"}, v)
+
+	promise, resolver := NewDeferred[string]()
+	RejectAll([]Resolver[string]{resolver}, errors.New("batch failed"))
+	_, err = promise.Await(ctx)
+	requireError(t, err)
+}
+
+func TestNewDeferredConcurrentAwaiters(t *testing.T) {
+	promise, resolver := NewDeferred[string]()
+	results := make(chan Settled[string], 5)
+	for i := 0; i < cap(results); i++ {
+		go func() {
+			v, err := promise.Await(context.Background())
+			results <- Settled[string]{Value: v, Err: err}
+		}()
+	}
+	go resolver.Reject(errors.New("lost the race"))
+	resolver.Resolve("webhook payload")
+	first := <-results
+	for i := 1; i < cap(results); i++ {
+		requireEqual(t, first, <-results)
+	}
+}
@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DeferStack collects cleanup functions for an asynchronous scope, and runs
+// them in last-in-first-out order, just like defer does for a function. The
+// zero value is ready to use.
+type DeferStack struct {
+	mu  sync.Mutex
+	fns []func(context.Context) error
+}
+
+// Push registers a cleanup function to be run by Run.
+func (d *DeferStack) Push(fn func(context.Context) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fns = append(d.fns, fn)
+}
+
+// Run runs every registered cleanup function, one at a time, starting with the
+// one that was pushed last. A failing cleanup does not stop the others from
+// running; all of their errors are joined and returned. The stack is left
+// empty, so a second call to Run only runs cleanups pushed since the first.
+func (d *DeferStack) Run(ctx context.Context) error {
+	d.mu.Lock()
+	fns := d.fns
+	d.fns = nil
+	d.mu.Unlock()
+	var errs []error
+	for i := len(fns) - 1; i >= 0; i-- {
+		if err := fns[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,28 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDeferStack(t *testing.T) {
+	var stack DeferStack
+	var order []int
+	doh := errors.New("doh!")
+	for i := 1; i <= 3; i++ {
+		stack.Push(func(context.Context) error {
+			order = append(order, i)
+			if i == 2 {
+				return doh
+			}
+			return nil
+		})
+	}
+	err := stack.Run(context.Background())
+	requireEqual(t, true, errors.Is(err, doh))
+	requireEqual(t, []int{3, 2, 1}, order)
+
+	requireNoError(t, stack.Run(context.Background()))
+	requireEqual(t, []int{3, 2, 1}, order)
+}
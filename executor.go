@@ -0,0 +1,58 @@
+package async
+
+import (
+	"sync/atomic"
+)
+
+// Executor launches the work behind promises. Go must arrange for fn to be
+// run exactly once; it may do so on a new goroutine, a pooled one, or even the
+// calling goroutine. A *Pool is an Executor that bounds how many goroutines
+// do work at once.
+type Executor interface {
+	Go(fn func())
+}
+
+// GoroutineExecutor runs every function on a new goroutine. This is how work
+// is launched unless SetDefaultExecutor says otherwise.
+type GoroutineExecutor struct{}
+
+// Go runs fn on a new goroutine.
+func (GoroutineExecutor) Go(fn func()) { go fn() }
+
+// InlineExecutor runs every function on the calling goroutine, before Go
+// returns. This makes the work behind promises run one at a time, in a
+// predictable order, which suits tests; but a function that waits on
+// anything launched after it will deadlock.
+type InlineExecutor struct{}
+
+// Go runs fn and returns once it has.
+func (InlineExecutor) Go(fn func()) { fn() }
+
+var defaultExecutor atomic.Pointer[Executor]
+
+// SetDefaultExecutor sets the executor used to launch work by NewPromise, and
+// by the functions that run work over a slice, like Map and AllLimit, so that
+// a program may cap the concurrency of all of its asynchronous work in one
+// place. Awaiting is not work: combinators like All keep using goroutines of
+// their own to wait on promises, so that a saturated executor cannot stop
+// promises from being awaited. Bear in mind that some functions, like
+// FromChannel and the nodes of a Graph, launch work that itself waits, and
+// that a bounded executor whose every worker is taken up by such waiting work
+// will deadlock. A nil e restores the GoroutineExecutor. The default executor
+// should be set before any work is launched.
+func SetDefaultExecutor(e Executor) {
+	if e == nil {
+		defaultExecutor.Store(nil)
+		return
+	}
+	defaultExecutor.Store(&e)
+}
+
+// launch runs fn on the default executor.
+func launch(fn func()) {
+	if e := defaultExecutor.Load(); e != nil {
+		(*e).Go(fn)
+		return
+	}
+	go fn()
+}
@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInlineExecutor(t *testing.T) {
+	p := NewPromise(func() (int, error) { return 1, nil }, WithExecutor(InlineExecutor{}))
+	requireEqual(t, true, p.Settled())
+}
+
+func TestSetDefaultExecutor(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPool(1)
+	defer pool.Close()
+	SetDefaultExecutor(pool)
+	defer SetDefaultExecutor(nil)
+
+	var running, peak atomic.Int32
+	work := func(ctx context.Context, i int) (int, error) {
+		defer trackPeak(&running, &peak)()
+		time.Sleep(time.Millisecond * 5)
+		return i, nil
+	}
+	v, err := Map(ctx, []int{1, 2, 3}, work)
+	requireNoError(t, err)
+	requireEqual(t, []int{1, 2, 3}, v)
+	promises := []Promise[int]{
+		NewPromise(func() (int, error) { return work(ctx, 4) }),
+		NewPromise(func() (int, error) { return work(ctx, 5) }),
+	}
+	ints, err := All(ctx, promises)
+	requireNoError(t, err)
+	requireEqual(t, []int{4, 5}, ints)
+	requireEqual(t, int32(1), peak.Load())
+	requireEqual(t, int64(5), pool.Stats().TotalSubmitted)
+}
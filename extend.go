@@ -0,0 +1,56 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+// AwaitWithExtend awaits p for up to base. Each time that deadline is reached
+// without p having settled, extend is called: if it returns true, the deadline
+// is pushed back by another base, otherwise the await gives up with
+// context.DeadlineExceeded. This models waiting for as long as the caller
+// confirms the result is still wanted.
+func AwaitWithExtend[T any](ctx context.Context, p Promise[T], base time.Duration, extend func() bool) (T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	result := awaitEach(ctx, []Promise[T]{p})
+	timer := time.NewTimer(base)
+	defer timer.Stop()
+	for {
+		select {
+		case r := <-result:
+			return r.v, r.err
+		case <-timer.C:
+			if !extend() {
+				var zerov T
+				return zerov, context.DeadlineExceeded
+			}
+			timer.Reset(base)
+		}
+	}
+}
+
+// AwaitGraceful awaits p, but should ctx be cancelled first, keeps waiting for
+// up to grace longer, so that work which is nearly done is not thrown away.
+// If p settles within the grace period its result is returned as usual;
+// otherwise the error of ctx is.
+func AwaitGraceful[T any](ctx context.Context, p Promise[T], grace time.Duration) (T, error) {
+	awaitCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	result := awaitEach(awaitCtx, []Promise[T]{p})
+	select {
+	case r := <-result:
+		return r.v, r.err
+	case <-ctx.Done():
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case r := <-result:
+		return r.v, r.err
+	case <-timer.C:
+		var zerov T
+		return zerov, ctx.Err()
+	}
+}
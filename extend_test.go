@@ -0,0 +1,48 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAwaitWithExtend(t *testing.T) {
+	ctx := context.Background()
+	base := time.Millisecond * 40
+	slow := func() Promise[string] {
+		return NewPromise(func() (string, error) {
+			time.Sleep(base * 5 / 2)
+			return "done", nil
+		})
+	}
+	extensions := 0
+	v, err := AwaitWithExtend(ctx, slow(), base, func() bool {
+		extensions++
+		return extensions <= 2
+	})
+	requireNoError(t, err)
+	requireEqual(t, "done", v)
+	requireEqual(t, 2, extensions)
+
+	_, err = AwaitWithExtend(ctx, slow(), base, func() bool { return false })
+	requireEqual(t, context.DeadlineExceeded, err)
+}
+
+func TestAwaitGraceful(t *testing.T) {
+	ctx := context.Background()
+	cctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	v, err := AwaitGraceful(cctx, NewPromise(func() (string, error) {
+		time.Sleep(time.Millisecond * 30)
+		return "nearly done", nil
+	}), time.Millisecond*200)
+	requireNoError(t, err)
+	requireEqual(t, "nearly done", v)
+
+	cctx, cancel = context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	probe := newCancelProbe[string]()
+	_, err = AwaitGraceful[string](cctx, probe, time.Millisecond*20)
+	requireEqual(t, context.DeadlineExceeded, err)
+	requireCancelled(t, probe)
+}
@@ -0,0 +1,71 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type flushBatch[T any] struct {
+	items []T
+	timer *time.Timer
+	done  *syncPromise[struct{}]
+}
+
+// Flusher accumulates items and hands them off in batches, which is useful
+// for batching writes like log shipping or bulk inserts.
+type Flusher[T any] struct {
+	flush    func(context.Context, []T) error
+	maxItems int
+	maxWait  time.Duration
+
+	mu      sync.Mutex
+	current *flushBatch[T]
+}
+
+// NewFlusher creates a Flusher that calls flush with a batch of items once
+// either maxItems have been added, or maxWait has passed since the first item
+// of the batch was added.
+func NewFlusher[T any](flush func(ctx context.Context, items []T) error, maxItems int, maxWait time.Duration) *Flusher[T] {
+	return &Flusher[T]{
+		flush:    flush,
+		maxItems: maxItems,
+		maxWait:  maxWait,
+	}
+}
+
+// Add buffers item, and returns a promise that settles once the batch that
+// includes the item has been flushed. Should the flush fail, every promise for
+// the items in that batch is rejected with its error.
+func (f *Flusher[T]) Add(item T) Promise[struct{}] {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b := f.current
+	if b == nil {
+		b = &flushBatch[T]{done: newSyncPromise[struct{}]()}
+		b.timer = time.AfterFunc(f.maxWait, func() {
+			f.mu.Lock()
+			if f.current != b {
+				f.mu.Unlock()
+				return
+			}
+			f.current = nil
+			f.mu.Unlock()
+			f.run(b)
+		})
+		f.current = b
+	}
+	b.items = append(b.items, item)
+	if len(b.items) >= f.maxItems {
+		b.timer.Stop()
+		f.current = nil
+		go f.run(b)
+	}
+	return b.done
+}
+
+func (f *Flusher[T]) run(b *flushBatch[T]) {
+	b.done.settle(safeCall(func() (struct{}, error) {
+		return struct{}{}, f.flush(context.Background(), b.items)
+	}))
+}
@@ -0,0 +1,55 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlusher(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var batches [][]int
+	f := NewFlusher(func(ctx context.Context, items []int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, items)
+		return nil
+	}, 3, time.Millisecond*20)
+
+	first := []Promise[struct{}]{f.Add(1), f.Add(2), f.Add(3)}
+	_, err := All(ctx, first)
+	requireNoError(t, err)
+	rest := []Promise[struct{}]{f.Add(4), f.Add(5)}
+	requireEqual(t, false, rest[0].Settled())
+	_, err = All(ctx, rest)
+	requireNoError(t, err)
+	mu.Lock()
+	requireEqual(t, [][]int{{1, 2, 3}, {4, 5}}, batches)
+	mu.Unlock()
+
+	doh := errors.New("doh!")
+	f = NewFlusher(func(ctx context.Context, items []int) error {
+		return doh
+	}, 2, time.Second)
+	failed := []Promise[struct{}]{f.Add(1), f.Add(2)}
+	_, err = All(ctx, failed)
+	requireEqual(t, true, errors.Is(err, doh))
+	for _, p := range failed {
+		_, err = p.Await(ctx)
+		requireEqual(t, doh, err)
+	}
+}
+
+func TestFlusherPanic(t *testing.T) {
+	ctx := context.Background()
+	f := NewFlusher(func(ctx context.Context, items []int) error {
+		panic("flush exploded")
+	}, 1, time.Second)
+	_, err := f.Add(1).Await(ctx)
+	var panicErr *PanicError
+	requireEqual(t, true, errors.As(err, &panicErr))
+	requireEqual(t, any("flush exploded"), panicErr.Value)
+}
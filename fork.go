@@ -0,0 +1,19 @@
+package async
+
+import (
+	"context"
+)
+
+// Fork runs n independent copies of fn concurrently, each in its own
+// goroutine, and returns a promise for each of them. This is useful for shadow
+// or canary comparisons, where the outputs of the copies are diffed. Since
+// there is no parent context, each copy is given context.Background().
+func Fork[T any](fn func(context.Context) (T, error), n int) []Promise[T] {
+	promises := make([]Promise[T], n)
+	for i := range promises {
+		promises[i] = NewPromise(func() (T, error) {
+			return fn(context.Background())
+		})
+	}
+	return promises
+}
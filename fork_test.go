@@ -0,0 +1,28 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFork(t *testing.T) {
+	var calls atomic.Int32
+	promises := Fork(func(context.Context) (int32, error) {
+		n := calls.Add(1)
+		if n == 2 {
+			return 0, errors.New("canary failed")
+		}
+		return n, nil
+	}, 3)
+	requireEqual(t, 3, len(promises))
+	failed := 0
+	for _, p := range promises {
+		if _, err := p.Await(context.Background()); err != nil {
+			failed++
+		}
+	}
+	requireEqual(t, int32(3), calls.Load())
+	requireEqual(t, 1, failed)
+}
@@ -0,0 +1,38 @@
+package async
+
+import (
+	"context"
+)
+
+// Future wraps a promise with a handful of convenience methods for getting at
+// its result. It does not change how the promise settles; every method simply
+// defers to the wrapped promise.
+type Future[T any] struct {
+	p Promise[T]
+}
+
+// NewFuture wraps p in a Future.
+func NewFuture[T any](p Promise[T]) Future[T] {
+	return Future[T]{p: p}
+}
+
+// Get awaits the promise.
+func (f Future[T]) Get(ctx context.Context) (T, error) {
+	return f.p.Await(ctx)
+}
+
+// GetOr awaits the promise like AwaitOr.
+func (f Future[T]) GetOr(ctx context.Context, def T) T {
+	return AwaitOr(ctx, f.p, def)
+}
+
+// Must awaits the promise like MustAwait.
+func (f Future[T]) Must(ctx context.Context) T {
+	return MustAwait(ctx, f.p)
+}
+
+// IsReady reports whether the promise has settled, so that Get would not
+// block.
+func (f Future[T]) IsReady() bool {
+	return f.p.Settled()
+}
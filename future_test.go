@@ -0,0 +1,38 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFuture(t *testing.T) {
+	ctx := context.Background()
+	ok := NewFuture(Resolve(42))
+	requireEqual(t, true, ok.IsReady())
+	v, err := ok.Get(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 42, v)
+	requireEqual(t, 42, ok.GetOr(ctx, 7))
+	requireEqual(t, 42, ok.Must(ctx))
+
+	doh := errors.New("doh!")
+	failed := NewFuture(Reject[int](doh))
+	requireEqual(t, true, failed.IsReady())
+	_, err = failed.Get(ctx)
+	requireEqual(t, doh, err)
+	requireEqual(t, 7, failed.GetOr(ctx, 7))
+	func() {
+		defer func() {
+			requireEqual(t, any(doh), recover())
+		}()
+		failed.Must(ctx)
+		t.Fatal("expected Must to panic")
+	}()
+
+	pending, resolver := NewDeferred[int]()
+	f := NewFuture(pending)
+	requireEqual(t, false, f.IsReady())
+	resolver.Resolve(1)
+	requireEqual(t, true, f.IsReady())
+}
@@ -1,3 +1,3 @@
 module code.nkcmr.net/async
 
-go 1.18
+go 1.23
@@ -0,0 +1,136 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrGraphCycle is returned by Graph.Run when the dependencies between nodes
+// form a cycle.
+var ErrGraphCycle = errors.New("async: dependency cycle in graph")
+
+type graphNode struct {
+	name string
+	deps []string
+	fn   func(context.Context, map[string]any) (any, error)
+}
+
+// Graph orchestrates a set of named tasks with dependencies between them. Each
+// task runs once all of the tasks it depends upon have completed, and tasks
+// that do not depend on each other run concurrently.
+type Graph struct {
+	nodes []graphNode
+}
+
+// NewGraph creates an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{}
+}
+
+// AddNode registers a task named name which depends on the tasks named in
+// deps. When run, fn receives the results of its dependencies keyed by name.
+func (g *Graph) AddNode(name string, deps []string, fn func(ctx context.Context, inputs map[string]any) (any, error)) {
+	g.nodes = append(g.nodes, graphNode{name: name, deps: deps, fn: fn})
+}
+
+// Run executes every task in the graph respecting their dependencies and
+// returns the results of all tasks keyed by name. The graph is validated
+// before anything runs; duplicate names, unknown dependencies and cycles
+// (reported as ErrGraphCycle) are all errors. The first task to fail cancels
+// the context given to the others, and its error is returned.
+func (g *Graph) Run(ctx context.Context) (map[string]any, error) {
+	byName := make(map[string]graphNode, len(g.nodes))
+	for _, n := range g.nodes {
+		if _, dup := byName[n.name]; dup {
+			return nil, fmt.Errorf("async: duplicate graph node %q", n.name)
+		}
+		byName[n.name] = n
+	}
+	for _, n := range g.nodes {
+		for _, dep := range n.deps {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("async: graph node %q depends on unknown node %q", n.name, dep)
+			}
+		}
+	}
+	if err := g.checkCycles(byName); err != nil {
+		return nil, err
+	}
+
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	promises := make(map[string]Promise[any], len(g.nodes))
+	var start func(name string) Promise[any]
+	start = func(name string) Promise[any] {
+		if p, ok := promises[name]; ok {
+			return p
+		}
+		n := byName[name]
+		deps := make([]Promise[any], len(n.deps))
+		for i, dep := range n.deps {
+			deps[i] = start(dep)
+		}
+		p := NewPromise(func() (any, error) {
+			values, err := All(ctx, deps)
+			if err != nil {
+				// positions in deps mean nothing to the caller, so drop the
+				// IndexedError that All tags the failure with.
+				return nil, errors.Unwrap(err)
+			}
+			inputs := make(map[string]any, len(n.deps))
+			for i, dep := range n.deps {
+				inputs[dep] = values[i]
+			}
+			return n.fn(ctx, inputs)
+		})
+		promises[name] = p
+		return p
+	}
+	all := make([]Promise[any], len(g.nodes))
+	for i, n := range g.nodes {
+		all[i] = start(n.name)
+	}
+	values, err := All(ctx, all)
+	if err != nil {
+		return nil, errors.Unwrap(err)
+	}
+	out := make(map[string]any, len(g.nodes))
+	for i, n := range g.nodes {
+		out[n.name] = values[i]
+	}
+	return out, nil
+}
+
+func (g *Graph) checkCycles(byName map[string]graphNode) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(byName))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("%w: via %q", ErrGraphCycle, name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range byName[name].deps {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, n := range g.nodes {
+		if err := visit(n.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
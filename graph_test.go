@@ -0,0 +1,57 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGraph(t *testing.T) {
+	ctx := context.Background()
+	var running, peak atomic.Int32
+	middle := func(ctx context.Context, inputs map[string]any) (any, error) {
+		defer trackPeak(&running, &peak)()
+		time.Sleep(time.Millisecond * 30)
+		return inputs["a"].(int) + 1, nil
+	}
+	g := NewGraph()
+	g.AddNode("d", []string{"b", "c"}, func(ctx context.Context, inputs map[string]any) (any, error) {
+		return inputs["b"].(int) + inputs["c"].(int), nil
+	})
+	g.AddNode("b", []string{"a"}, middle)
+	g.AddNode("c", []string{"a"}, middle)
+	var runningAtStart int32
+	g.AddNode("a", nil, func(ctx context.Context, inputs map[string]any) (any, error) {
+		runningAtStart = running.Load()
+		return 1, nil
+	})
+	results, err := g.Run(ctx)
+	requireNoError(t, err)
+	requireEqual(t, map[string]any{"a": 1, "b": 2, "c": 2, "d": 4}, results)
+	requireEqual(t, int32(0), runningAtStart)
+	requireEqual(t, int32(2), peak.Load())
+
+	ran := false
+	noop := func(ctx context.Context, inputs map[string]any) (any, error) {
+		ran = true
+		return nil, nil
+	}
+	g = NewGraph()
+	g.AddNode("x", []string{"z"}, noop)
+	g.AddNode("y", []string{"x"}, noop)
+	g.AddNode("z", []string{"y"}, noop)
+	_, err = g.Run(ctx)
+	requireEqual(t, true, errors.Is(err, ErrGraphCycle))
+	requireEqual(t, false, ran)
+
+	doh := errors.New("doh!")
+	g = NewGraph()
+	g.AddNode("a", nil, func(ctx context.Context, inputs map[string]any) (any, error) {
+		return nil, doh
+	})
+	g.AddNode("b", []string{"a"}, noop)
+	_, err = g.Run(ctx)
+	requireEqual(t, doh, err)
+}
@@ -0,0 +1,82 @@
+package async
+
+import (
+	"sync"
+)
+
+// Group deduplicates concurrent work by key, in the manner of singleflight:
+// while work for a key is in flight, every call for that key shares the same
+// execution and the same promise. The zero value is ready to use.
+type Group[K comparable, T any] struct {
+	mu     sync.Mutex
+	calls  map[K]*groupCall[T]
+	cached map[K]Promise[T]
+}
+
+type groupCall[T any] struct {
+	p *syncPromise[T]
+
+	// keep is set once any caller asks for the result to be cached.
+	keep bool
+}
+
+// NewGroup creates a new Group.
+func NewGroup[K comparable, T any]() *Group[K, T] {
+	return &Group[K, T]{}
+}
+
+// Do returns the in-flight promise for key, or if there is none, runs fn in a
+// new goroutine and returns a promise for its result. Once that promise
+// settles the key is forgotten, and the next call to Do starts new work.
+func (g *Group[K, T]) Do(key K, fn func() (T, error)) Promise[T] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.do(key, fn, false)
+}
+
+// DoCached is like Do, except that a successful result is kept, and returned
+// by every later call to DoCached for key without running fn again. Failures
+// are not kept, so that the next call tries again. Do never returns a cached
+// result.
+func (g *Group[K, T]) DoCached(key K, fn func() (T, error)) Promise[T] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if p, ok := g.cached[key]; ok {
+		return p
+	}
+	return g.do(key, fn, true)
+}
+
+// Forget drops the cached result for key, if there is one.
+func (g *Group[K, T]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.cached, key)
+}
+
+// do must be called with g.mu held.
+func (g *Group[K, T]) do(key K, fn func() (T, error), keep bool) Promise[T] {
+	if c, ok := g.calls[key]; ok {
+		c.keep = c.keep || keep
+		return c.p
+	}
+	if g.calls == nil {
+		g.calls = make(map[K]*groupCall[T])
+	}
+	c := &groupCall[T]{p: newSyncPromise[T](), keep: keep}
+	g.calls[key] = c
+	go func() {
+		v, err := safeCall(fn)
+		g.mu.Lock()
+		delete(g.calls, key)
+		if c.keep && err == nil {
+			if g.cached == nil {
+				g.cached = make(map[K]Promise[T])
+			}
+			g.cached[key] = c.p
+		}
+		g.mu.Unlock()
+		c.p.settle(v, err)
+	}()
+	return c.p
+}
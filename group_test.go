@@ -0,0 +1,76 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	ctx := context.Background()
+	var g Group[int, int]
+	var calls atomic.Int32
+	fn := func() (int, error) {
+		time.Sleep(time.Millisecond * 20)
+		return int(calls.Add(1)), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]Settled[int], 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := g.Do(7, fn).Await(ctx)
+			results[i] = Settled[int]{Value: v, Err: err}
+		}(i)
+	}
+	wg.Wait()
+	requireEqual(t, int32(1), calls.Load())
+	for _, r := range results {
+		requireEqual(t, Settled[int]{Value: 1}, r)
+	}
+
+	v, err := g.Do(7, fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 2, v)
+}
+
+func TestGroupDoCached(t *testing.T) {
+	ctx := context.Background()
+	g := NewGroup[string, int]()
+	var calls atomic.Int32
+	fn := func() (int, error) {
+		return int(calls.Add(1)), nil
+	}
+	v, err := g.DoCached("key", fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+	v, err = g.DoCached("key", fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+
+	v, err = g.Do("key", fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 2, v)
+
+	g.Forget("key")
+	v, err = g.DoCached("key", fn).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 3, v)
+
+	doh := errors.New("doh!")
+	failures := 0
+	failing := func() (int, error) {
+		failures++
+		return 0, doh
+	}
+	_, err = g.DoCached("failing", failing).Await(ctx)
+	requireEqual(t, doh, err)
+	_, err = g.DoCached("failing", failing).Await(ctx)
+	requireEqual(t, doh, err)
+	requireEqual(t, 2, failures)
+}
@@ -0,0 +1,105 @@
+package async
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// Hooks are callbacks that observe the lifecycle of promises, for monitoring.
+// Any of them may be nil. They are called synchronously, from whichever
+// goroutine the event happens on, so they should be quick and safe for
+// concurrent use.
+type Hooks struct {
+	// Created is called when NewPromise creates a promise, with the name it
+	// was given by WithName, if any.
+	Created func(name string)
+
+	// Started is called when the function behind a promise created by
+	// NewPromise starts running.
+	Started func(name string)
+
+	// Settled is called when the function behind a promise created by
+	// NewPromise has returned, with how long it ran for and its error.
+	Settled func(name string, d time.Duration, err error)
+
+	// AwaitCancelled is called, with the context error, whenever an await of
+	// a promise that has not settled yet gives up because its context is
+	// done. That includes the awaits that combinators like All, Race and Any
+	// cancel themselves once they have their answer, not only those given up
+	// on by callers.
+	AwaitCancelled func(err error)
+}
+
+var hooks atomic.Pointer[Hooks]
+
+// SetHooks installs h as the hooks observing every promise from then on. A nil
+// h removes the hooks.
+func SetHooks(h *Hooks) {
+	hooks.Store(h)
+}
+
+// Metrics is a ready-made set of Hooks that counts promises as they go through
+// their lifecycle. The zero value is ready to use.
+type Metrics struct {
+	created   atomic.Int64
+	settled   atomic.Int64
+	rejected  atomic.Int64
+	cancelled atomic.Int64
+	runNanos  atomic.Int64
+}
+
+// MetricsSnapshot is a point-in-time view of Metrics.
+type MetricsSnapshot struct {
+	// Created is the number of promises created.
+	Created int64
+
+	// Pending is the number of promises created that have not yet settled.
+	Pending int64
+
+	// Settled is the number of promises that have settled, and Rejected the
+	// number of those that failed.
+	Settled, Rejected int64
+
+	// AwaitsCancelled is the number of awaits that gave up before the promise
+	// settled, as counted by Hooks.AwaitCancelled.
+	AwaitsCancelled int64
+
+	// TotalRunTime is how long the functions behind the settled promises have
+	// run for, all told.
+	TotalRunTime time.Duration
+}
+
+// Hooks returns the hooks that feed m, to be passed to SetHooks.
+func (m *Metrics) Hooks() *Hooks {
+	return &Hooks{
+		Created: func(string) { m.created.Add(1) },
+		Settled: func(_ string, d time.Duration, err error) {
+			m.runNanos.Add(int64(d))
+			if err != nil {
+				m.rejected.Add(1)
+			}
+			m.settled.Add(1)
+		},
+		AwaitCancelled: func(error) { m.cancelled.Add(1) },
+	}
+}
+
+// Snapshot returns the current counts.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	settled := m.settled.Load()
+	return MetricsSnapshot{
+		Created:         m.created.Load(),
+		Pending:         m.created.Load() - settled,
+		Settled:         settled,
+		Rejected:        m.rejected.Load(),
+		AwaitsCancelled: m.cancelled.Load(),
+		TotalRunTime:    time.Duration(m.runNanos.Load()),
+	}
+}
+
+// Publish exports the snapshot of m as an expvar variable with the given name.
+// Like expvar.Publish, it panics if the name is already in use.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return m.Snapshot() }))
+}
@@ -0,0 +1,81 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+)
+
+func TestHooks(t *testing.T) {
+	var started []string
+	settled := make(chan error, 1)
+	SetHooks(&Hooks{
+		Started: func(name string) { started = append(started, name) },
+		Settled: func(name string, d time.Duration, err error) {
+			if name != "fetch" || d < time.Millisecond*5 {
+				t.Errorf("unexpected settle of %q after %v", name, d)
+			}
+			settled <- err
+		},
+	})
+	defer SetHooks(nil)
+
+	doh := errors.New("doh!")
+	p := NewPromise(func() (int, error) {
+		time.Sleep(time.Millisecond * 5)
+		return 0, doh
+	}, WithName("fetch"), WithExecutor(InlineExecutor{}))
+	requireEqual(t, true, p.Settled())
+	requireEqual(t, []string{"fetch"}, started)
+	requireEqual(t, doh, <-settled)
+}
+
+func TestMetrics(t *testing.T) {
+	var m Metrics
+	SetHooks(m.Hooks())
+	defer SetHooks(nil)
+
+	ctx := context.Background()
+	release := make(chan struct{})
+	pending := NewPromise(func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	settled := []Promise[int]{
+		NewPromise(func() (int, error) { return 1, nil }),
+		NewPromise(func() (int, error) { return 0, errors.New("doh!") }),
+	}
+	for _, p := range settled {
+		p.Await(ctx)
+	}
+	_, err := All(ctx, settled)
+	requireError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	_, err = pending.Await(timeoutCtx)
+	requireEqual(t, context.DeadlineExceeded, err)
+
+	s := m.Snapshot()
+	requireEqual(t, int64(3), s.Created)
+	requireEqual(t, int64(1), s.Pending)
+	requireEqual(t, int64(1), s.Rejected)
+	requireEqual(t, int64(1), s.AwaitsCancelled)
+
+	// All cancels its await of pending once the other promise has failed,
+	// and that counts too.
+	_, err = All(ctx, []Promise[int]{Reject[int](errors.New("doh!")), pending})
+	requireError(t, err)
+	requireEqual(t, int64(2), m.Snapshot().AwaitsCancelled)
+
+	close(release)
+	_, err = pending.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int64(0), m.Snapshot().Pending)
+
+	if expvar.Get("async_test_metrics") == nil {
+		m.Publish("async_test_metrics")
+	}
+	requireEqual(t, true, expvar.Get("async_test_metrics") != nil)
+}
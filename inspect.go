@@ -0,0 +1,98 @@
+package async
+
+import "context"
+
+// State is where a promise is in its lifecycle.
+type State int
+
+const (
+	// Pending means the promise has not settled yet.
+	Pending State = iota
+
+	// Fulfilled means the promise resolved with a value.
+	Fulfilled
+
+	// Rejected means the promise failed with an error.
+	Rejected
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Fulfilled:
+		return "fulfilled"
+	case Rejected:
+		return "rejected"
+	}
+	return "unknown"
+}
+
+// Inspectable is implemented by promises that can report on their progress
+// without blocking. The promises from NewPromise, NewDeferred, Resolve and
+// Reject are Inspectable, as are those of functions that hand one of them
+// back, like Retry, and those from SingleUse. Wrappers such as Then,
+// WithTimeout and NewLazyPromise are not; StateOf and TryAwait inspect those
+// through Settled instead.
+type Inspectable[T any] interface {
+	Promise[T]
+
+	// State reports whether the promise is pending, fulfilled or rejected.
+	State() State
+
+	// TryAwait returns the result of the promise if it has settled, along
+	// with true. Should the promise still be pending, it returns immediately
+	// with the zero value, a nil error and false.
+	TryAwait() (T, error, bool)
+}
+
+// StateOf reports the state of p, which need not be Inspectable.
+func StateOf[T any](p Promise[T]) State {
+	if i, ok := p.(Inspectable[T]); ok {
+		return i.State()
+	}
+	_, err, ok := TryAwait(p)
+	return stateOf(err, ok)
+}
+
+// TryAwait returns the result of p without blocking, if it has settled, along
+// with true. Should p still be pending, it returns the zero value, a nil error
+// and false. Unlike the method of Inspectable, p need not implement it.
+func TryAwait[T any](p Promise[T]) (T, error, bool) {
+	if i, ok := p.(Inspectable[T]); ok {
+		return i.TryAwait()
+	}
+	if !p.Settled() {
+		var zerov T
+		return zerov, nil, false
+	}
+	v, err := p.Await(context.Background())
+	return v, err, true
+}
+
+func (s *syncPromise[T]) State() State {
+	_, err, ok := s.TryAwait()
+	return stateOf(err, ok)
+}
+
+func (s *syncPromise[T]) TryAwait() (T, error, bool) {
+	if !s.settled.Load() {
+		var zerov T
+		return zerov, nil, false
+	}
+	return s.v, s.err, true
+}
+
+func (r *rp[T]) State() State { return stateOf(r.err, true) }
+
+func (r *rp[T]) TryAwait() (T, error, bool) { return r.v, r.err, true }
+
+func stateOf(err error, settled bool) State {
+	switch {
+	case !settled:
+		return Pending
+	case err != nil:
+		return Rejected
+	}
+	return Fulfilled
+}
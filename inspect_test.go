@@ -0,0 +1,40 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// opaquePromise hides every method of a promise but those of Promise.
+type opaquePromise[T any] struct{ Promise[T] }
+
+func TestStateOf(t *testing.T) {
+	release := make(chan struct{})
+	p := NewPromise(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	requireEqual(t, Pending, StateOf(p))
+	_, _, ok := TryAwait(p)
+	requireEqual(t, false, ok)
+	close(release)
+	_, err := p.Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, Fulfilled, StateOf(p))
+	v, err, ok := TryAwait(p)
+	requireNoError(t, err)
+	requireEqual(t, true, ok)
+	requireEqual(t, 1, v)
+
+	doh := errors.New("doh!")
+	requireEqual(t, Rejected, StateOf(Reject[int](doh)))
+	requireEqual(t, "rejected", Rejected.String())
+
+	// Promises that are not Inspectable are inspected through Settled.
+	plain := opaquePromise[int]{Resolve(2)}
+	requireEqual(t, Fulfilled, StateOf[int](plain))
+	v, _, ok = TryAwait[int](plain)
+	requireEqual(t, true, ok)
+	requireEqual(t, 2, v)
+}
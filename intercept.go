@@ -0,0 +1,12 @@
+package async
+
+// NewPromiseIntercepted is like NewPromise, but first wraps fn with
+// interceptor, which may run code around fn, or replace it altogether. This is
+// a seam for tests to inject faults or latency into real work without
+// changing it. A nil interceptor runs fn as is.
+func NewPromiseIntercepted[T any](interceptor func(func() (T, error)) func() (T, error), fn func() (T, error)) Promise[T] {
+	if interceptor != nil {
+		fn = interceptor(fn)
+	}
+	return NewPromise(fn)
+}
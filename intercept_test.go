@@ -0,0 +1,36 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewPromiseIntercepted(t *testing.T) {
+	ctx := context.Background()
+	work := func() (string, error) { return "real", nil }
+
+	injected := errors.New("injected")
+	_, err := NewPromiseIntercepted(func(next func() (string, error)) func() (string, error) {
+		return func() (string, error) { return "", injected }
+	}, work).Await(ctx)
+	requireEqual(t, injected, err)
+
+	start := time.Now()
+	v, err := NewPromiseIntercepted(func(next func() (string, error)) func() (string, error) {
+		return func() (string, error) {
+			time.Sleep(time.Millisecond * 30)
+			return next()
+		}
+	}, work).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "real", v)
+	if time.Since(start) < time.Millisecond*30 {
+		t.Fatal("expected the interceptor to delay the work")
+	}
+
+	v, err = NewPromiseIntercepted(nil, work).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "real", v)
+}
@@ -0,0 +1,44 @@
+package async
+
+import (
+	"sync"
+)
+
+// Latch is a counter that settles a promise once it has been incremented a
+// target number of times.
+type Latch struct {
+	mu     sync.Mutex
+	count  int
+	target int
+	done   *syncPromise[struct{}]
+}
+
+// NewLatch creates a Latch that is released once Count has been called target
+// times. A Latch with a target of zero or less starts out released.
+func NewLatch(target int) *Latch {
+	l := &Latch{
+		target: target,
+		done:   newSyncPromise[struct{}](),
+	}
+	if target <= 0 {
+		l.done.settle(struct{}{}, nil)
+	}
+	return l
+}
+
+// Count increments the latch, releasing it if the target has been reached.
+// Counts beyond the target have no effect.
+func (l *Latch) Count() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.count++
+	if l.count >= l.target {
+		l.done.settle(struct{}{}, nil)
+	}
+}
+
+// Wait returns a promise that resolves once the latch has been released. Every
+// promise returned by Wait settles at the same time.
+func (l *Latch) Wait() Promise[struct{}] {
+	return l.done
+}
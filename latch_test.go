@@ -0,0 +1,22 @@
+package async
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLatch(t *testing.T) {
+	latch := NewLatch(3)
+	first, second := latch.Wait(), latch.Wait()
+	latch.Count()
+	latch.Count()
+	requireEqual(t, false, first.Settled())
+	latch.Count()
+	requireEqual(t, true, first.Settled())
+	requireEqual(t, true, second.Settled())
+	latch.Count()
+
+	_, err := second.Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, true, NewLatch(0).Wait().Settled())
+}
@@ -0,0 +1,48 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+type lazyPromise[T any] struct {
+	*syncPromise[T]
+	start sync.Once
+	fn    func() (T, error)
+}
+
+func (l *lazyPromise[T]) Await(ctx context.Context) (T, error) {
+	l.start.Do(func() {
+		go func() {
+			l.settle(safeCall(l.fn))
+		}()
+	})
+	return l.syncPromise.Await(ctx)
+}
+
+// NewLazyPromise is like NewPromise, except that fn is not started until the
+// promise is first awaited. From then on it behaves like any other promise:
+// fn runs exactly once, in its own goroutine, no matter how many callers
+// await concurrently, and they all receive the same result. This avoids
+// paying for work that nobody ends up waiting for.
+func NewLazyPromise[T any](fn func() (T, error)) Promise[T] {
+	return &lazyPromise[T]{
+		syncPromise: newSyncPromise[T](),
+		fn:          fn,
+	}
+}
+
+// NewLazyPromiseContext is like NewLazyPromise, except that fn is given a
+// context in the manner of NewPromiseContext: it is derived from ctx, and is
+// cancelled once the promise settles, or when ctx is done, in which case the
+// promise rejects with the context error. A ctx that is done before the
+// promise is first awaited rejects it without calling fn.
+func NewLazyPromiseContext[T any](ctx context.Context, fn func(context.Context) (T, error)) Promise[T] {
+	return NewLazyPromise(func() (T, error) {
+		if err := ctx.Err(); err != nil {
+			var zerov T
+			return zerov, err
+		}
+		return NewPromiseContext(ctx, fn).Await(context.Background())
+	})
+}
@@ -0,0 +1,67 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewLazyPromise(t *testing.T) {
+	var calls atomic.Int32
+	promise := NewLazyPromise(func() (int, error) {
+		time.Sleep(time.Millisecond * 10)
+		return int(calls.Add(1)), nil
+	})
+	time.Sleep(time.Millisecond * 20)
+	requireEqual(t, int32(0), calls.Load())
+	requireEqual(t, false, promise.Settled())
+
+	var wg sync.WaitGroup
+	results := make([]Settled[int], 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := promise.Await(context.Background())
+			results[i] = Settled[int]{Value: v, Err: err}
+		}()
+	}
+	wg.Wait()
+	for _, r := range results {
+		requireEqual(t, Settled[int]{Value: 1}, r)
+	}
+	requireEqual(t, int32(1), calls.Load())
+	requireEqual(t, true, promise.Settled())
+}
+
+func TestNewLazyPromiseContext(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	p := NewLazyPromiseContext(ctx, func(ctx context.Context) (int, error) {
+		return int(calls.Add(1)), nil
+	})
+	time.Sleep(time.Millisecond * 10)
+	requireEqual(t, int32(0), calls.Load())
+	for i := 0; i < 2; i++ {
+		v, err := p.Await(ctx)
+		requireNoError(t, err)
+		requireEqual(t, 1, v)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	p = NewLazyPromiseContext(cctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		close(stopped)
+		return 0, ctx.Err()
+	})
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		cancel()
+	}()
+	_, err := p.Await(ctx)
+	requireEqual(t, context.Canceled, err)
+	<-stopped
+}
@@ -0,0 +1,57 @@
+package async
+
+import (
+	"context"
+)
+
+type concurrencyLimitKey struct{}
+
+// WithConcurrencyLimit returns a copy of ctx that carries a concurrency budget
+// of n. All promises created with NewPromiseLimited from the returned context
+// (or any context derived from it) share that budget, so at most n of their
+// functions will be running at any one time.
+func WithConcurrencyLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, concurrencyLimitKey{}, make(chan struct{}, n))
+}
+
+// NewPromiseLimited is like NewPromise, but waits for a slot from the
+// concurrency budget attached to ctx by WithConcurrencyLimit before running fn.
+// If ctx is cancelled while waiting for a slot, fn is never run and the promise
+// is rejected with the context error. If ctx carries no budget, fn is started
+// immediately.
+func NewPromiseLimited[T any](ctx context.Context, fn func() (T, error)) Promise[T] {
+	slots, _ := ctx.Value(concurrencyLimitKey{}).(chan struct{})
+	if slots == nil {
+		return NewPromise(fn)
+	}
+	return NewPromise(func() (T, error) {
+		select {
+		case <-ctx.Done():
+			var zerov T
+			return zerov, ctx.Err()
+		case slots <- struct{}{}:
+		}
+		defer func() { <-slots }()
+		return fn()
+	})
+}
+
+// AllLimit runs the functions with at most limit of them running at a time,
+// starting the next function as soon as a running one returns, and returns
+// their results in input order. If limit is not positive, every function is
+// started at once. Like All, the first error short-circuits, and no further
+// functions are started once it has occurred.
+func AllLimit[T any](ctx context.Context, limit int, fns []func() (T, error)) ([]T, error) {
+	return mapLimit(ctx, fns, limit, func(_ context.Context, fn func() (T, error)) (T, error) {
+		return fn()
+	})
+}
+
+// AllLimitContext is like AllLimit, except that each function is given a
+// context, which is cancelled once any of them fails, so that the functions
+// still running can stop early.
+func AllLimitContext[T any](ctx context.Context, limit int, fns []func(context.Context) (T, error)) ([]T, error) {
+	return mapLimit(ctx, fns, limit, func(ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+		return fn(ctx)
+	})
+}
@@ -0,0 +1,100 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewPromiseLimited(t *testing.T) {
+	ctx := WithConcurrencyLimit(context.Background(), 2)
+	var running, peak atomic.Int32
+	promises := make([]Promise[int], 6)
+	for i := range promises {
+		promises[i] = NewPromiseLimited(ctx, func() (int, error) {
+			defer trackPeak(&running, &peak)()
+			time.Sleep(time.Millisecond * 20)
+			return 0, nil
+		})
+	}
+	_, err := All(ctx, promises)
+	requireNoError(t, err)
+	requireEqual(t, int32(2), peak.Load())
+
+	cctx, cancel := context.WithCancel(WithConcurrencyLimit(context.Background(), 1))
+	block := NewPromiseLimited(cctx, func() (int, error) {
+		time.Sleep(time.Millisecond * 50)
+		return 1, nil
+	})
+	time.Sleep(time.Millisecond * 10)
+	waiting := NewPromiseLimited(cctx, func() (int, error) {
+		t.Error("function should not run once its context is cancelled")
+		return 2, nil
+	})
+	cancel()
+	_, err = waiting.Await(context.Background())
+	requireEqual(t, context.Canceled, err)
+	_, _ = block.Await(context.Background())
+}
+
+func TestAllLimit(t *testing.T) {
+	ctx := context.Background()
+	var running, peak atomic.Int32
+	fns := make([]func() (int, error), 6)
+	for i := range fns {
+		fns[i] = func() (int, error) {
+			defer trackPeak(&running, &peak)()
+			time.Sleep(time.Millisecond * 10)
+			return i, nil
+		}
+	}
+	v, err := AllLimit(ctx, 2, fns)
+	requireNoError(t, err)
+	requireEqual(t, []int{0, 1, 2, 3, 4, 5}, v)
+	requireEqual(t, int32(2), peak.Load())
+
+	var started atomic.Int32
+	_, err = AllLimit(ctx, 1, []func() (int, error){
+		func() (int, error) {
+			started.Add(1)
+			return 0, errors.New("doh!")
+		},
+		func() (int, error) {
+			started.Add(1)
+			return 1, nil
+		},
+	})
+	requireError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	requireEqual(t, int32(1), started.Load())
+}
+
+func TestAllLimitContext(t *testing.T) {
+	ctx := context.Background()
+	v, err := AllLimitContext(ctx, 2, []func(context.Context) (int, error){
+		func(context.Context) (int, error) { return 1, nil },
+		func(context.Context) (int, error) { return 2, nil },
+		func(context.Context) (int, error) { return 3, nil },
+	})
+	requireNoError(t, err)
+	requireEqual(t, []int{1, 2, 3}, v)
+
+	doh := errors.New("doh!")
+	stopped := make(chan struct{})
+	_, err = AllLimitContext(ctx, 2, []func(context.Context) (int, error){
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			close(stopped)
+			return 0, ctx.Err()
+		},
+		func(context.Context) (int, error) { return 0, doh },
+	})
+	requireEqual(t, doh, err)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected the running function to be cancelled")
+	}
+}
@@ -0,0 +1,56 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLimiterFull is returned by TryGoLimited when every slot of the Limiter is
+// taken.
+var ErrLimiterFull = errors.New("async: limiter is full")
+
+// Limiter bounds how much work runs at once across unrelated call sites, to
+// protect a downstream service from unbounded fan-out. Work is started with
+// GoLimited or TryGoLimited, and holds its weight in slots of the Limiter for
+// as long as it runs, so that heavier work can be made to count for more.
+type Limiter struct {
+	sem *Semaphore
+}
+
+// NewLimiter creates a Limiter with n slots.
+func NewLimiter(n int) *Limiter {
+	return &Limiter{sem: NewSemaphore(n)}
+}
+
+// GoLimited returns a promise for the result of fn, which runs in a new
+// goroutine once weight slots of l are free. Slots are handed out in the order
+// that they were asked for. If ctx is cancelled while waiting for slots, fn is
+// never run and the promise rejects with the context error. A weight below one,
+// or above the size of l, is rejected without running fn.
+func GoLimited[T any](ctx context.Context, l *Limiter, weight int, fn func(context.Context) (T, error)) Promise[T] {
+	acquired := l.sem.AcquireN(ctx, weight)
+	return NewPromise(func() (T, error) {
+		// The semaphore gives up on the slots itself when ctx is cancelled, so
+		// awaiting it with ctx could lose slots that were handed out.
+		release, err := acquired.Await(context.Background())
+		if err != nil {
+			var zerov T
+			return zerov, err
+		}
+		defer release()
+		return fn(ctx)
+	})
+}
+
+// TryGoLimited is like GoLimited, except that should weight slots of l not be
+// free right away, fn is not run and the promise rejects with ErrLimiterFull.
+func TryGoLimited[T any](ctx context.Context, l *Limiter, weight int, fn func(context.Context) (T, error)) Promise[T] {
+	release, ok := l.sem.TryAcquireN(weight)
+	if !ok {
+		return Reject[T](ErrLimiterFull)
+	}
+	return NewPromise(func() (T, error) {
+		defer release()
+		return fn(ctx)
+	})
+}
@@ -0,0 +1,73 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(2)
+	var running, peak atomic.Int32
+	work := func(context.Context) (int, error) {
+		defer trackPeak(&running, &peak)()
+		time.Sleep(time.Millisecond * 10)
+		return 0, nil
+	}
+	var promises []Promise[int]
+	for range 5 {
+		promises = append(promises, GoLimited(ctx, l, 1, work))
+	}
+	_, err := TryGoLimited(ctx, l, 1, work).Await(ctx)
+	requireEqual(t, ErrLimiterFull, err)
+	_, err = All(ctx, promises)
+	requireNoError(t, err)
+	requireEqual(t, int32(2), peak.Load())
+
+	v, err := TryGoLimited(ctx, l, 1, func(context.Context) (int, error) { return 7, nil }).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 7, v)
+
+	release := make(chan struct{})
+	block := func(context.Context) (int, error) {
+		<-release
+		return 0, nil
+	}
+	held := []Promise[int]{GoLimited(ctx, l, 1, block), GoLimited(ctx, l, 1, block)}
+	cctx, cancel := context.WithCancel(ctx)
+	ran := false
+	waiting := GoLimited(cctx, l, 1, func(context.Context) (int, error) {
+		ran = true
+		return 0, nil
+	})
+	cancel()
+	_, err = waiting.Await(ctx)
+	requireEqual(t, context.Canceled, err)
+	requireEqual(t, false, ran)
+	close(release)
+	_, err = All(ctx, held)
+	requireNoError(t, err)
+}
+
+func TestLimiterWeighted(t *testing.T) {
+	ctx := context.Background()
+	l := NewLimiter(3)
+	release := make(chan struct{})
+	heavy := GoLimited(ctx, l, 2, func(context.Context) (int, error) {
+		<-release
+		return 2, nil
+	})
+	light, err := TryGoLimited(ctx, l, 1, func(context.Context) (int, error) { return 1, nil }).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, light)
+	_, err = TryGoLimited(ctx, l, 2, func(context.Context) (int, error) { return 0, nil }).Await(ctx)
+	requireEqual(t, ErrLimiterFull, err)
+	close(release)
+	_, err = heavy.Await(ctx)
+	requireNoError(t, err)
+
+	_, err = GoLimited(ctx, l, 4, func(context.Context) (int, error) { return 0, nil }).Await(ctx)
+	requireError(t, err)
+}
@@ -0,0 +1,84 @@
+package async
+
+import (
+	"context"
+)
+
+type linkedCancellationPromise[T, U any] struct {
+	trigger Promise[T]
+	p       Promise[U]
+
+	// result is settled with the first outcome seen, so that it never
+	// changes afterwards.
+	result *syncPromise[U]
+}
+
+// settleIfReady settles the result without blocking if either p has settled,
+// which takes precedence, or trigger has been rejected.
+func (l *linkedCancellationPromise[T, U]) settleIfReady() {
+	if l.result.Settled() {
+		return
+	}
+	if l.p.Settled() {
+		l.result.settle(l.p.Await(context.Background()))
+		return
+	}
+	if l.trigger.Settled() {
+		if _, err := l.trigger.Await(context.Background()); err != nil {
+			var zerov U
+			l.result.settle(zerov, err)
+		}
+	}
+}
+
+func (l *linkedCancellationPromise[T, U]) Settled() bool {
+	l.settleIfReady()
+	return l.result.Settled()
+}
+
+func (l *linkedCancellationPromise[T, U]) Await(ctx context.Context) (U, error) {
+	var zerov U
+	if l.settleIfReady(); l.result.Settled() {
+		return l.result.Await(ctx)
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	result := awaitEach(ctx, []Promise[U]{l.p})
+	trigger := awaitEach(ctx, []Promise[T]{l.trigger})
+	for {
+		select {
+		case r := <-result:
+			if r.err != nil && ctx.Err() != nil && !l.p.Settled() {
+				return zerov, r.err
+			}
+			l.result.settle(r.v, r.err)
+			return l.result.Await(context.Background())
+		case t := <-trigger:
+			if t.err == nil {
+				trigger = nil // resolved successfully, so it will never cancel p.
+				continue
+			}
+			if ctx.Err() != nil && !l.trigger.Settled() {
+				return zerov, t.err
+			}
+			l.result.settle(zerov, t.err)
+			return l.result.Await(context.Background())
+		}
+	}
+}
+
+// LinkCancellation returns a promise that settles with the result of p,
+// unless trigger is rejected first, in which case waiting on p is abandoned
+// and the error of trigger is returned instead. This lets the failure of one
+// promise cut short the wait for another, like a "connection closed" promise
+// aborting a pending read. Should trigger resolve successfully, it has no
+// effect. Once the returned promise has settled, its result never changes: a
+// trigger that rejects after p has settled is ignored.
+func LinkCancellation[T, U any](trigger Promise[T], p Promise[U]) Promise[U] {
+	return &linkedCancellationPromise[T, U]{
+		trigger: trigger,
+		p:       p,
+		result:  newSyncPromise[U](),
+	}
+}
@@ -0,0 +1,44 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLinkCancellation(t *testing.T) {
+	ctx := context.Background()
+	closed := errors.New("connection closed")
+	conn := NewPromise(func() (struct{}, error) {
+		time.Sleep(time.Millisecond * 10)
+		return struct{}{}, closed
+	})
+	read := LinkCancellation[struct{}, string](conn, newCancelProbe[string]())
+	_, err := read.Await(ctx)
+	requireEqual(t, closed, err)
+	requireEqual(t, true, read.Settled())
+
+	read = LinkCancellation(Resolve(struct{}{}), NewPromise(func() (string, error) {
+		time.Sleep(time.Millisecond * 10)
+		return "data", nil
+	}))
+	v, err := read.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "data", v)
+}
+
+func TestLinkCancellationSettledStaysSettled(t *testing.T) {
+	ctx := context.Background()
+	trigger, r := NewDeferred[struct{}]()
+	linked := LinkCancellation(trigger, Resolve("data"))
+	v, err := linked.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "data", v)
+
+	r.Reject(errors.New("connection closed"))
+	v, err = linked.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "data", v)
+	requireEqual(t, true, linked.Settled())
+}
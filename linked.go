@@ -0,0 +1,35 @@
+package async
+
+import (
+	"context"
+)
+
+// NewPromiseContext runs fn in a new goroutine, like NewPromise, but gives it a
+// context derived from ctx, so that the work may be cancelled. That context is
+// cancelled once the promise settles, or when ctx is done, in which case the
+// promise also rejects with the context error right away. Callers of Await
+// giving up does not cancel the work, since the promise may always be awaited
+// again later.
+func NewPromiseContext[T any](ctx context.Context, fn func(context.Context) (T, error)) Promise[T] {
+	p, _ := NewLinkedPromise(ctx, fn)
+	return p
+}
+
+// NewLinkedPromise is like NewPromiseContext, but also returns the child
+// context of parent that is given to fn, so that the same context may be
+// passed to downstream work, keeping cancellation coherent over the whole
+// sub-tree.
+func NewLinkedPromise[T any](parent context.Context, fn func(context.Context) (T, error)) (Promise[T], context.Context) {
+	ctx, cancel := context.WithCancel(parent)
+	c := newSyncPromise[T]()
+	go func() {
+		<-ctx.Done()
+		var zerov T
+		c.settle(zerov, ctx.Err())
+	}()
+	go func() {
+		defer cancel()
+		c.settle(safeCall(func() (T, error) { return fn(ctx) }))
+	}()
+	return c, ctx
+}
@@ -0,0 +1,53 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewLinkedPromise(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	promise, child := NewLinkedPromise(parent, func(ctx context.Context) (int, error) {
+		time.Sleep(time.Second) // ignores its context on purpose
+		return 1, nil
+	})
+	requireNoError(t, child.Err())
+	cancel()
+	_, err := promise.Await(context.Background())
+	requireEqual(t, context.Canceled, err)
+	requireEqual(t, context.Canceled, child.Err())
+
+	promise, child = NewLinkedPromise(context.Background(), func(ctx context.Context) (int, error) {
+		return 2, nil
+	})
+	v, err := promise.Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, 2, v)
+	<-child.Done()
+}
+
+func TestNewPromiseContext(t *testing.T) {
+	work := make(chan context.Context, 1)
+	promise := NewPromiseContext(context.Background(), func(ctx context.Context) (int, error) {
+		work <- ctx
+		return 1, nil
+	})
+	v, err := promise.Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+	select {
+	case <-(<-work).Done():
+	case <-time.After(time.Second):
+		t.Fatal("work context was not cancelled once the promise settled")
+	}
+
+	parent, cancel := context.WithCancel(context.Background())
+	promise = NewPromiseContext(parent, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	cancel()
+	_, err = promise.Await(context.Background())
+	requireEqual(t, context.Canceled, err)
+}
@@ -0,0 +1,33 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotAlive is returned by AwaitWithLiveness when its liveness check fails
+// before the promise settles.
+var ErrNotAlive = errors.New("async: liveness check failed")
+
+// ErrInvalidInterval is returned by AwaitWithLiveness when it is given an
+// interval that is not positive.
+var ErrInvalidInterval = errors.New("async: polling interval must be positive")
+
+// AwaitWithLiveness awaits p, calling alive every interval while it waits. As
+// soon as alive returns false, the await is abandoned with ErrNotAlive. This
+// allows waiting to stop when some external condition changes (like a client
+// disconnecting), independent of ctx. An interval that is not positive is
+// rejected with ErrInvalidInterval, without awaiting p.
+func AwaitWithLiveness[T any](ctx context.Context, p Promise[T], interval time.Duration, alive func() bool) (T, error) {
+	if interval <= 0 {
+		var zerov T
+		return zerov, ErrInvalidInterval
+	}
+	return awaitPolling(ctx, p, interval, func() error {
+		if !alive() {
+			return ErrNotAlive
+		}
+		return nil
+	})
+}
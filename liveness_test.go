@@ -0,0 +1,29 @@
+package async
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAwaitWithLiveness(t *testing.T) {
+	ctx := context.Background()
+	var connected atomic.Bool
+	connected.Store(true)
+	time.AfterFunc(time.Millisecond*20, func() { connected.Store(false) })
+
+	start := time.Now()
+	_, err := AwaitWithLiveness[int](ctx, newCancelProbe[int](), time.Millisecond*5, connected.Load)
+	requireEqual(t, ErrNotAlive, err)
+	if elapsed := time.Since(start); elapsed > time.Millisecond*500 {
+		t.Fatalf("liveness failure took %s to be noticed", elapsed)
+	}
+
+	v, err := AwaitWithLiveness(ctx, Resolve(1), time.Millisecond, func() bool { return true })
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+
+	_, err = AwaitWithLiveness(ctx, Resolve(1), 0, func() bool { return true })
+	requireEqual(t, ErrInvalidInterval, err)
+}
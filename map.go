@@ -0,0 +1,110 @@
+package async
+
+import (
+	"context"
+)
+
+// MapIndexed awaits all of the promises and transforms each value with fn,
+// which also receives the index of the promise the value came from. The
+// transformed values are returned in input order. An error from either a
+// promise or fn short-circuits the whole operation, cancelling the remaining
+// awaits.
+func MapIndexed[T, U any](ctx context.Context, promises []Promise[T], fn func(i int, v T) (U, error)) ([]U, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	out := make([]U, len(promises))
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		u, err := fn(r.index, r.v)
+		if err != nil {
+			return nil, err
+		}
+		out[r.index] = u
+	}
+	return out, nil
+}
+
+// Map runs fn over each of the items concurrently, and returns the results in
+// input order. The first error short-circuits, cancelling the context given to
+// the other calls.
+func Map[In, Out any](ctx context.Context, in []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	return mapLimit(ctx, in, 0, fn)
+}
+
+// MapLimit is like Map, but with at most limit calls in flight at a time (or
+// all at once if limit is not positive). No further items are started once an
+// error has occurred.
+func MapLimit[In, Out any](ctx context.Context, in []In, limit int, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	return mapLimit(ctx, in, limit, fn)
+}
+
+// MapSkip runs fn over each of the items concurrently, with at most limit
+// calls in flight at a time (or all at once if limit is not positive), and
+// returns the results in input order. Before anything is started, every item
+// is checked with pre; the first item that pre rejects fails the whole batch
+// with that error, without fn being called for any item. Otherwise, the first
+// error from fn short-circuits, cancelling the context given to the other
+// calls.
+func MapSkip[T, U any](ctx context.Context, items []T, limit int, pre func(T) error, fn func(context.Context, T) (U, error)) ([]U, error) {
+	for _, item := range items {
+		if err := pre(item); err != nil {
+			return nil, err
+		}
+	}
+	return mapLimit(ctx, items, limit, fn)
+}
+
+// mapLimit runs fn over each of the items with at most limit calls in flight
+// at a time (unbounded if limit is not positive). Results are returned in
+// input order, and the first error cancels the context given to fn and stops
+// any further items from being started.
+func mapLimit[T, U any](ctx context.Context, items []T, limit int, fn func(context.Context, T) (U, error)) ([]U, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	var slots chan struct{}
+	if limit > 0 {
+		slots = make(chan struct{}, limit)
+	}
+	results := make(chan outcome[U], len(items))
+	go func() {
+		for i, item := range items {
+			if slots != nil {
+				select {
+				case <-ctx.Done():
+				case slots <- struct{}{}:
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				results <- outcome[U]{index: i, err: err}
+				return
+			}
+			launch(func() {
+				v, err := safeCall(func() (U, error) { return fn(ctx, item) })
+				if err != nil {
+					// cancel before giving up the slot, so that no further
+					// items are started in its place.
+					cancel()
+				}
+				if slots != nil {
+					<-slots
+				}
+				results <- outcome[U]{index: i, v: v, err: err}
+			})
+		}
+	}()
+	out := make([]U, len(items))
+	for range items {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		out[r.index] = r.v
+	}
+	return out, nil
+}
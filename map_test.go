@@ -0,0 +1,89 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMapIndexed(t *testing.T) {
+	ctx := context.Background()
+	promises := []Promise[string]{Resolve("a"), Resolve("b"), Resolve("c")}
+	out, err := MapIndexed(ctx, promises, func(i int, v string) (string, error) {
+		return fmt.Sprintf("%d:%s", i, v), nil
+	})
+	requireNoError(t, err)
+	requireEqual(t, []string{"0:a", "1:b", "2:c"}, out)
+
+	out, err = MapIndexed(ctx, promises, func(i int, v string) (string, error) {
+		if i == 1 {
+			return "", errors.New("bad index")
+		}
+		return v, nil
+	})
+	requireError(t, err)
+	requireEqual(t, []string(nil), out)
+}
+
+func TestMapSkip(t *testing.T) {
+	ctx := context.Background()
+	var called atomic.Int32
+	double := func(ctx context.Context, i int) (int, error) {
+		called.Add(1)
+		return i * 2, nil
+	}
+	nonNegative := func(i int) error {
+		if i < 0 {
+			return errors.New("negative")
+		}
+		return nil
+	}
+	out, err := MapSkip(ctx, []int{1, 2, 3}, 2, nonNegative, double)
+	requireNoError(t, err)
+	requireEqual(t, []int{2, 4, 6}, out)
+	requireEqual(t, int32(3), called.Load())
+
+	called.Store(0)
+	_, err = MapSkip(ctx, []int{1, -2, 3}, 0, nonNegative, double)
+	requireError(t, err)
+	requireEqual(t, "negative", err.Error())
+	requireEqual(t, int32(0), called.Load())
+}
+
+func TestMap(t *testing.T) {
+	ctx := context.Background()
+	out, err := Map(ctx, []int{1, 2, 3}, func(ctx context.Context, i int) (string, error) {
+		return fmt.Sprint(i * 2), nil
+	})
+	requireNoError(t, err)
+	requireEqual(t, []string{"2", "4", "6"}, out)
+
+	doh := errors.New("doh!")
+	stopped := make(chan struct{})
+	_, err = Map(ctx, []int{1, 2}, func(ctx context.Context, i int) (string, error) {
+		if i == 2 {
+			return "", doh
+		}
+		<-ctx.Done()
+		close(stopped)
+		return "", ctx.Err()
+	})
+	requireEqual(t, doh, err)
+	<-stopped
+}
+
+func TestMapLimit(t *testing.T) {
+	ctx := context.Background()
+	var running, peak atomic.Int32
+	out, err := MapLimit(ctx, []int{1, 2, 3, 4}, 2, func(ctx context.Context, i int) (int, error) {
+		defer trackPeak(&running, &peak)()
+		time.Sleep(time.Millisecond * 10)
+		return i * i, nil
+	})
+	requireNoError(t, err)
+	requireEqual(t, []int{1, 4, 9, 16}, out)
+	requireEqual(t, int32(2), peak.Load())
+}
@@ -0,0 +1,46 @@
+package async
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrNotSettled is returned by operations that require a promise to have
+// already settled.
+var ErrNotSettled = errors.New("async: promise is not settled")
+
+type marshaledResult[T any] struct {
+	Value T
+	Error string `json:",omitempty"`
+}
+
+// MarshalResult JSON-encodes the result of a settled promise, so that it may be
+// persisted and later restored with UnmarshalResult. Errors are stored only as
+// their message, so the restored error will not match the original with
+// errors.Is. Attempting to marshal a promise that has not yet settled returns
+// ErrNotSettled.
+func MarshalResult[T any](p Promise[T]) ([]byte, error) {
+	if !p.Settled() {
+		return nil, ErrNotSettled
+	}
+	v, err := p.Await(context.Background())
+	r := marshaledResult[T]{Value: v}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return json.Marshal(r)
+}
+
+// UnmarshalResult decodes data produced by MarshalResult into a promise that is
+// already settled with the stored value or error.
+func UnmarshalResult[T any](data []byte) (Promise[T], error) {
+	var r marshaledResult[T]
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	if r.Error != "" {
+		return Reject[T](errors.New(r.Error)), nil
+	}
+	return Resolve(r.Value), nil
+}
@@ -0,0 +1,35 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMarshalResult(t *testing.T) {
+	ctx := context.Background()
+	data, err := MarshalResult(Resolve(42))
+	requireNoError(t, err)
+	p, err := UnmarshalResult[int](data)
+	requireNoError(t, err)
+	requireEqual(t, true, p.Settled())
+	v, err := p.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 42, v)
+
+	data, err = MarshalResult(Reject[int](errors.New("darn")))
+	requireNoError(t, err)
+	p, err = UnmarshalResult[int](data)
+	requireNoError(t, err)
+	_, err = p.Await(ctx)
+	requireError(t, err)
+	requireEqual(t, "darn", err.Error())
+
+	block := make(chan struct{})
+	defer close(block)
+	_, err = MarshalResult(NewPromise(func() (int, error) {
+		<-block
+		return 0, nil
+	}))
+	requireEqual(t, ErrNotSettled, err)
+}
@@ -0,0 +1,35 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"time"
+)
+
+// ErrMemLimit is returned by AwaitMemLimit when heap allocation grows beyond
+// the permitted limit before the promise settles.
+var ErrMemLimit = errors.New("async: memory limit exceeded")
+
+// memLimitPollInterval controls how often AwaitMemLimit samples memory stats.
+const memLimitPollInterval = time.Millisecond * 100
+
+// AwaitMemLimit awaits p, but gives up with ErrMemLimit if the heap allocation
+// reported by the runtime exceeds maxAlloc bytes while waiting.
+//
+// This is a heuristic safety valve, not an accounting mechanism: the heap is
+// shared by the whole process, so growth caused by unrelated goroutines counts
+// against the limit just the same. Memory is only sampled periodically (since
+// runtime.ReadMemStats briefly stops the world), so short spikes between
+// samples can go unnoticed. Giving up on the await does not stop the work
+// behind p, nor free any memory it holds.
+func AwaitMemLimit[T any](ctx context.Context, p Promise[T], maxAlloc uint64) (T, error) {
+	var stats runtime.MemStats
+	return awaitPolling(ctx, p, memLimitPollInterval, func() error {
+		runtime.ReadMemStats(&stats)
+		if stats.HeapAlloc > maxAlloc {
+			return ErrMemLimit
+		}
+		return nil
+	})
+}
@@ -0,0 +1,22 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAwaitMemLimit(t *testing.T) {
+	ctx := context.Background()
+	v, err := AwaitMemLimit(ctx, Resolve(1), 1<<40)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+
+	// any running program has more than a single byte allocated, so this
+	// simulates an await that has blown through its budget.
+	_, err = AwaitMemLimit(ctx, NewPromise(func() (int, error) {
+		time.Sleep(time.Second)
+		return 1, nil
+	}), 1)
+	requireEqual(t, ErrMemLimit, err)
+}
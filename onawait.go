@@ -0,0 +1,29 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+type onAwaitDonePromise[T any] struct {
+	Promise[T]
+	once    sync.Once
+	cleanup func()
+}
+
+func (o *onAwaitDonePromise[T]) Await(ctx context.Context) (T, error) {
+	v, err := o.Promise.Await(ctx)
+	o.once.Do(o.cleanup)
+	return v, err
+}
+
+// OnAwaitDone wraps p so that cleanup runs, on the awaiting goroutine, right
+// after the first call to Await on the returned promise returns. This is tied
+// to the await call rather than to the promise settling: cleanup runs even if
+// that first await gave up because its context was cancelled while p was still
+// pending, and it never runs if the promise is never awaited. This makes it
+// suited to releasing resources held on behalf of the awaiting caller, like a
+// borrowed buffer.
+func OnAwaitDone[T any](p Promise[T], cleanup func()) Promise[T] {
+	return &onAwaitDonePromise[T]{Promise: p, cleanup: cleanup}
+}
@@ -0,0 +1,25 @@
+package async
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOnAwaitDone(t *testing.T) {
+	calls := 0
+	probe := newCancelProbe[int]()
+	promise := OnAwaitDone[int](probe, func() { calls++ })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := promise.Await(ctx)
+	requireEqual(t, context.Canceled, err)
+	requireEqual(t, false, promise.Settled())
+	requireEqual(t, 1, calls)
+
+	calls = 0
+	promise = OnAwaitDone(Resolve(1), func() { calls++ })
+	_, _ = promise.Await(context.Background())
+	_, _ = promise.Await(context.Background())
+	requireEqual(t, 1, calls)
+}
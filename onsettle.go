@@ -0,0 +1,26 @@
+package async
+
+import "context"
+
+// OnSettle arranges for fn to be called exactly once with the result of p once
+// it has settled, regardless of who, if anyone, awaits p. If p has already
+// settled, fn is called right away. For the promises made by this package,
+// such as those from NewPromise, fn is called on the goroutine that settles
+// the promise, without starting a goroutine of its own, so it should be quick;
+// for any other promise, a goroutine awaits it on behalf of fn.
+func OnSettle[T any](p Promise[T], fn func(T, error)) {
+	switch p := p.(type) {
+	case *syncPromise[T]:
+		p.onSettle(fn)
+	case *rp[T]:
+		fn(p.v, p.err)
+	default:
+		if p.Settled() {
+			fn(p.Await(context.Background()))
+			return
+		}
+		go func() {
+			fn(p.Await(context.Background()))
+		}()
+	}
+}
@@ -0,0 +1,33 @@
+package async
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOnSettle(t *testing.T) {
+	release := make(chan struct{})
+	p := NewPromise(func() (int, error) {
+		<-release
+		return 1, nil
+	})
+	got := make(chan int, 2)
+	OnSettle(p, func(v int, err error) { got <- v })
+	OnSettle(p, func(v int, err error) { got <- v * 10 })
+	close(release)
+	requireEqual(t, 1, <-got)
+	requireEqual(t, 10, <-got)
+
+	var late int
+	OnSettle(p, func(v int, err error) { late = v })
+	requireEqual(t, 1, late)
+
+	doh := errors.New("doh!")
+	var rejected error
+	OnSettle(Reject[int](doh), func(_ int, err error) { rejected = err })
+	requireEqual(t, doh, rejected)
+
+	done := make(chan error, 1)
+	OnSettle(Then(p, func(v int) (int, error) { return 0, doh }), func(_ int, err error) { done <- err })
+	requireEqual(t, doh, <-done)
+}
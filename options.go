@@ -0,0 +1,87 @@
+package async
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// Option tunes how NewPromise runs its function.
+type Option func(*promiseOptions)
+
+type promiseOptions struct {
+	name      string
+	norecover bool
+	executor  Executor
+}
+
+// WithName names the promise, for debugging. The function behind the promise
+// runs with the pprof label "async.promise" set to name, so that its goroutine
+// can be told apart in goroutine profiles.
+func WithName(name string) Option {
+	return func(o *promiseOptions) { o.name = name }
+}
+
+// WithRecover sets whether a panic in the function behind the promise is
+// recovered into a *PanicError, which is the default. Turning it off lets the
+// panic crash the program as it would from any other goroutine, for those who
+// would rather fail fast.
+func WithRecover(enabled bool) Option {
+	return func(o *promiseOptions) { o.norecover = !enabled }
+}
+
+// WithExecutor has the function behind the promise launched by e rather than
+// by the default executor.
+func WithExecutor(e Executor) Option {
+	return func(o *promiseOptions) { o.executor = e }
+}
+
+// run runs fn on its executor, as tuned by the options, handing its result to
+// settle.
+func run[T any](opts []Option, fn func() (T, error), settle func(T, error)) {
+	var o promiseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if h := hooks.Load(); h != nil {
+		if h.Created != nil {
+			h.Created(o.name)
+		}
+		var start time.Time
+		unstarted := fn
+		fn = func() (T, error) {
+			if h.Started != nil {
+				h.Started(o.name)
+			}
+			start = time.Now()
+			return unstarted()
+		}
+		unobserved := settle
+		settle = func(v T, err error) {
+			if h.Settled != nil {
+				h.Settled(o.name, time.Since(start), err)
+			}
+			unobserved(v, err)
+		}
+	}
+	call := func() {
+		if o.norecover {
+			settle(fn())
+			return
+		}
+		settle(safeCall(fn))
+	}
+	if o.name != "" {
+		unlabeled := call
+		call = func() {
+			pprof.Do(context.Background(), pprof.Labels("async.promise", o.name), func(context.Context) {
+				unlabeled()
+			})
+		}
+	}
+	if o.executor == nil {
+		launch(call)
+		return
+	}
+	o.executor.Go(call)
+}
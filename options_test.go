@@ -0,0 +1,46 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// recordingExecutor runs work on the calling goroutine, recording any panic that
+// escapes it.
+type recordingExecutor struct {
+	launched int
+	panicked any
+}
+
+func (e *recordingExecutor) Go(fn func()) {
+	e.launched++
+	defer func() { e.panicked = recover() }()
+	fn()
+}
+
+func TestNewPromiseOptions(t *testing.T) {
+	ctx := context.Background()
+	e := &recordingExecutor{}
+	p := NewPromise(func() (string, error) {
+		return "inline", nil
+	}, WithExecutor(e), WithName("test"))
+	requireEqual(t, 1, e.launched)
+	requireEqual(t, true, p.Settled())
+	v, err := p.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "inline", v)
+
+	_, err = NewPromise(func() (int, error) {
+		panic("recovered")
+	}, WithExecutor(e)).Await(ctx)
+	var panicErr *PanicError
+	requireEqual(t, true, errors.As(err, &panicErr))
+	requireEqual(t, nil, e.panicked)
+
+	p2 := NewPromise(func() (int, error) {
+		panic("not recovered")
+	}, WithExecutor(e), WithRecover(false))
+	requireEqual(t, any("not recovered"), e.panicked)
+	requireEqual(t, false, p2.Settled())
+}
@@ -0,0 +1,39 @@
+package async
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error a promise is rejected with when the function behind
+// it panics. Rather than crashing the program from a background goroutine,
+// the panic is recovered and delivered to whoever awaits the promise.
+type PanicError struct {
+	// Value is the value that was passed to panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("async: recovered from panic: %v", p.Value)
+}
+
+// Unwrap returns the panic value if it is an error, so that errors.Is and
+// errors.As can see through to it.
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// safeCall calls fn, converting a panic into a *PanicError.
+func safeCall[T any](fn func() (T, error)) (v T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zerov T
+			v, err = zerov, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
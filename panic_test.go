@@ -0,0 +1,24 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPanicError(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewPromise(func() (int, error) {
+		panic("oh no")
+	}).Await(ctx)
+	var panicErr *PanicError
+	requireEqual(t, true, errors.As(err, &panicErr))
+	requireEqual(t, any("oh no"), panicErr.Value)
+	requireEqual(t, true, len(panicErr.Stack) > 0)
+
+	doh := errors.New("doh!")
+	_, err = NewPromise(func() (int, error) {
+		panic(doh)
+	}).Await(ctx)
+	requireEqual(t, true, errors.Is(err, doh))
+}
@@ -0,0 +1,202 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Stream is a lazy pipeline of values, built up in stages from a source such
+// as StreamFromSlice and run by Collect. Every stage runs concurrently with
+// the others, handing values downstream as soon as they are ready. The first
+// error from any stage cancels the whole pipeline, as does cancelling the
+// context given to Collect.
+type Stream[T any] struct {
+	open func(pl *pipeline) <-chan T
+}
+
+// pipeline is the shared state of a running Stream.
+type pipeline struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+}
+
+// stage runs fn on a new goroutine, which Collect waits for before settling.
+func (pl *pipeline) stage(fn func()) {
+	pl.wg.Add(1)
+	go func() {
+		defer pl.wg.Done()
+		fn()
+	}()
+}
+
+// send delivers v on out, reporting false if the pipeline was cancelled first.
+func send[T any](pl *pipeline, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-pl.ctx.Done():
+		return false
+	}
+}
+
+// StreamFromSlice returns a Stream of the items, in order.
+func StreamFromSlice[T any](items []T) Stream[T] {
+	return Stream[T]{open: func(pl *pipeline) <-chan T {
+		out := make(chan T)
+		pl.stage(func() {
+			defer close(out)
+			for _, v := range items {
+				if !send(pl, out, v) {
+					return
+				}
+			}
+		})
+		return out
+	}}
+}
+
+// StreamFromChannel returns a Stream of the values received from ch, which
+// ends when ch is closed.
+func StreamFromChannel[T any](ch <-chan T) Stream[T] {
+	return Stream[T]{open: func(pl *pipeline) <-chan T {
+		out := make(chan T)
+		pl.stage(func() {
+			defer close(out)
+			for {
+				select {
+				case <-pl.ctx.Done():
+					return
+				case v, ok := <-ch:
+					if !ok || !send(pl, out, v) {
+						return
+					}
+				}
+			}
+		})
+		return out
+	}}
+}
+
+// MapStream returns a Stream of the results of calling fn on each value of s,
+// in the order of s. Up to limit calls run at once; a limit of 0 or less
+// means one at a time. Should fn fail, the pipeline is cancelled with its
+// error.
+func MapStream[T, U any](s Stream[T], limit int, fn func(context.Context, T) (U, error)) Stream[U] {
+	if limit <= 0 {
+		limit = 1
+	}
+	return Stream[U]{open: func(pl *pipeline) <-chan U {
+		in := s.open(pl)
+		out := make(chan U)
+		sem := make(chan struct{}, limit)
+		queue := make(chan chan U, limit)
+		pl.stage(func() {
+			defer close(queue)
+			for v := range in {
+				select {
+				case sem <- struct{}{}:
+				case <-pl.ctx.Done():
+					return
+				}
+				if pl.ctx.Err() != nil {
+					return
+				}
+				result := make(chan U, 1)
+				pl.stage(func() {
+					defer func() { <-sem }()
+					u, err := safeCall(func() (U, error) { return fn(pl.ctx, v) })
+					if err != nil {
+						pl.cancel(err)
+						return
+					}
+					result <- u
+				})
+				if !send(pl, queue, result) {
+					return
+				}
+			}
+		})
+		pl.stage(func() {
+			defer close(out)
+			for result := range queue {
+				select {
+				case u := <-result:
+					if !send(pl, out, u) {
+						return
+					}
+				case <-pl.ctx.Done():
+					return
+				}
+			}
+		})
+		return out
+	}}
+}
+
+// FilterStream returns a Stream of the values of s for which keep reports
+// true.
+func FilterStream[T any](s Stream[T], keep func(T) bool) Stream[T] {
+	return Stream[T]{open: func(pl *pipeline) <-chan T {
+		in := s.open(pl)
+		out := make(chan T)
+		pl.stage(func() {
+			defer close(out)
+			for v := range in {
+				if keep(v) && !send(pl, out, v) {
+					return
+				}
+			}
+		})
+		return out
+	}}
+}
+
+// Batch returns a Stream that groups the values of s into slices of n, with
+// whatever is left over when s ends in a final, shorter slice.
+func Batch[T any](s Stream[T], n int) Stream[[]T] {
+	if n <= 0 {
+		n = 1
+	}
+	return Stream[[]T]{open: func(pl *pipeline) <-chan []T {
+		in := s.open(pl)
+		out := make(chan []T)
+		pl.stage(func() {
+			defer close(out)
+			batch := make([]T, 0, n)
+			for v := range in {
+				if batch = append(batch, v); len(batch) == n {
+					if !send(pl, out, batch) {
+						return
+					}
+					batch = make([]T, 0, n)
+				}
+			}
+			if len(batch) > 0 && pl.ctx.Err() == nil {
+				send(pl, out, batch)
+			}
+		})
+		return out
+	}}
+}
+
+// Collect runs the pipeline, and returns a promise for every value that comes
+// out of it, in order. The promise rejects with the first error from any
+// stage, or with the error of ctx should it be cancelled first. Either way,
+// the promise does not settle until every stage has stopped.
+func (s Stream[T]) Collect(ctx context.Context) Promise[[]T] {
+	return NewPromise(func() ([]T, error) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		pl := &pipeline{ctx: ctx, cancel: cancel}
+		out := []T{}
+		for v := range s.open(pl) {
+			out = append(out, v)
+		}
+		pl.wg.Wait()
+		if err := context.Cause(ctx); err != nil {
+			return nil, err
+		}
+		return out, nil
+	})
+}
@@ -0,0 +1,55 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	var running, peak atomic.Int32
+	details := MapStream(StreamFromSlice([]int{1, 2, 3, 4, 5, 6, 7}), 3, func(ctx context.Context, id int) (int, error) {
+		defer trackPeak(&running, &peak)()
+		time.Sleep(time.Millisecond * time.Duration(8-id))
+		return id * 10, nil
+	})
+	odd := FilterStream(details, func(v int) bool { return v%20 != 0 })
+	v, err := Batch(odd, 3).Collect(ctx).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, [][]int{{10, 30, 50}, {70}}, v)
+	requireEqual(t, true, peak.Load() <= 3)
+
+	ch := make(chan string, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+	s, err := StreamFromChannel(ch).Collect(ctx).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, []string{"a", "b"}, s)
+}
+
+func TestStreamError(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	var after atomic.Int32
+	_, err := MapStream(StreamFromSlice([]int{1, 2, 3, 4, 5}), 1, func(ctx context.Context, id int) (int, error) {
+		if id == 2 {
+			return 0, doh
+		}
+		if id > 2 {
+			after.Add(1)
+		}
+		return id, nil
+	}).Collect(ctx).Await(ctx)
+	requireEqual(t, doh, err)
+	requireEqual(t, int32(0), after.Load())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	p := StreamFromChannel(make(chan int)).Collect(cancelled)
+	cancel()
+	_, err = p.Await(ctx)
+	requireEqual(t, context.Canceled, err)
+}
@@ -0,0 +1,139 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPoolClosed is returned for work submitted to a Pool after it has been
+// closed.
+var ErrPoolClosed = errors.New("async: pool is closed")
+
+// PoolStats is a snapshot of the activity of a Pool.
+type PoolStats struct {
+	// QueueDepth is the number of submitted tasks waiting for a worker.
+	QueueDepth int64
+
+	// ActiveWorkers is the number of workers currently running a task.
+	ActiveWorkers int64
+
+	// TotalSubmitted is the number of tasks ever submitted to the pool.
+	TotalSubmitted int64
+
+	// TotalCompleted is the number of tasks that have finished running.
+	TotalCompleted int64
+}
+
+// Pool runs submitted work on a fixed number of worker goroutines, queueing
+// work while every worker is busy. This bounds the number of goroutines used by
+// large fan-outs.
+type Pool struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []func()
+	closed bool
+
+	queued    atomic.Int64
+	active    atomic.Int64
+	submitted atomic.Int64
+	completed atomic.Int64
+}
+
+// NewPool starts a Pool with the given number of workers, or with a single
+// worker should workers not be positive, so that submitted work always runs.
+// A Pool should be closed once it is no longer needed, to stop its workers.
+func NewPool(workers int) *Pool {
+	workers = max(workers, 1)
+	p := &Pool{}
+	p.cond = sync.NewCond(&p.mu)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *Pool) work() {
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.closed {
+			p.cond.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		task := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		p.mu.Unlock()
+
+		p.queued.Add(-1)
+		p.active.Add(1)
+		task()
+		p.active.Add(-1)
+		p.completed.Add(1)
+	}
+}
+
+func (p *Pool) submit(task func()) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	p.submitted.Add(1)
+	p.queued.Add(1)
+	p.queue = append(p.queue, task)
+	p.cond.Signal()
+	return true
+}
+
+// Close stops the pool from accepting new work. Work that has already been
+// submitted still runs, after which the workers exit.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	p.cond.Broadcast()
+}
+
+// Go queues fn to run on one of the workers of the pool, making a Pool an
+// Executor. Should the pool be closed, fn runs on a new goroutine instead, so
+// that the promise it settles is not left pending forever.
+func (p *Pool) Go(fn func()) {
+	if !p.submit(fn) {
+		go fn()
+	}
+}
+
+// Stats returns a snapshot of the activity of the pool.
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		QueueDepth:     p.queued.Load(),
+		ActiveWorkers:  p.active.Load(),
+		TotalSubmitted: p.submitted.Load(),
+		TotalCompleted: p.completed.Load(),
+	}
+}
+
+// Submit queues fn to run on one of the workers of pool, and returns a promise
+// for its result. If ctx is cancelled before a worker picks up fn, it is
+// skipped and the promise rejects with the context error. Work submitted to a
+// closed pool is rejected with ErrPoolClosed.
+func Submit[T any](ctx context.Context, pool *Pool, fn func(context.Context) (T, error)) Promise[T] {
+	c := newSyncPromise[T]()
+	ok := pool.submit(func() {
+		if err := ctx.Err(); err != nil {
+			var zerov T
+			c.settle(zerov, err)
+			return
+		}
+		c.settle(safeCall(func() (T, error) { return fn(ctx) }))
+	})
+	if !ok {
+		return Reject[T](ErrPoolClosed)
+	}
+	return c
+}
@@ -0,0 +1,52 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPool(2)
+	defer pool.Close()
+	release := make(chan struct{})
+	promises := make([]Promise[int], 5)
+	for i := range promises {
+		promises[i] = Submit(ctx, pool, func(context.Context) (int, error) {
+			<-release
+			return i, nil
+		})
+	}
+	deadline := time.Now().Add(time.Second)
+	for pool.Stats().ActiveWorkers < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	requireEqual(t, PoolStats{
+		QueueDepth:     3,
+		ActiveWorkers:  2,
+		TotalSubmitted: 5,
+	}, pool.Stats())
+
+	close(release)
+	v, err := All(ctx, promises)
+	requireNoError(t, err)
+	requireEqual(t, []int{0, 1, 2, 3, 4}, v)
+	for pool.Stats().TotalCompleted < 5 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	requireEqual(t, PoolStats{TotalSubmitted: 5, TotalCompleted: 5}, pool.Stats())
+
+	pool.Close()
+	_, err = Submit(ctx, pool, func(context.Context) (int, error) { return 0, nil }).Await(ctx)
+	requireEqual(t, ErrPoolClosed, err)
+}
+
+func TestPoolWithoutWorkers(t *testing.T) {
+	ctx := context.Background()
+	pool := NewPool(0)
+	defer pool.Close()
+	v, err := Submit(ctx, pool, func(context.Context) (int, error) { return 1, nil }).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+}
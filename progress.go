@@ -0,0 +1,103 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// ProgressUpdate describes how far along a long-running promise is.
+type ProgressUpdate struct {
+	// Percent is how much of the work is done, from 0 to 100.
+	Percent float64
+
+	// Message optionally describes what the work is currently doing.
+	Message string
+}
+
+// ProgressPromise is a promise that reports progress while it is pending.
+type ProgressPromise[T any] interface {
+	Promise[T]
+
+	// Progress returns the most recent update, or false if none has been
+	// reported yet.
+	Progress() (ProgressUpdate, bool)
+
+	// Subscribe returns a channel that receives progress updates as they are
+	// reported, starting with the most recent one, and is closed once the
+	// promise settles or ctx is done. A subscriber that falls behind only
+	// misses intermediate updates: the channel always holds the latest one.
+	Subscribe(ctx context.Context) <-chan ProgressUpdate
+}
+
+type progressPromise[T any] struct {
+	*syncPromise[T]
+
+	mu     sync.Mutex
+	latest ProgressUpdate
+	seen   bool
+	subs   map[chan ProgressUpdate]struct{}
+}
+
+// NewPromiseWithProgress runs fn in a new goroutine, like NewPromise, giving
+// it a report function with which to publish progress to the holders of the
+// promise. report must not be called after fn has returned.
+func NewPromiseWithProgress[T any](fn func(report func(ProgressUpdate)) (T, error)) ProgressPromise[T] {
+	p := &progressPromise[T]{
+		syncPromise: newSyncPromise[T](),
+		subs:        map[chan ProgressUpdate]struct{}{},
+	}
+	go func() {
+		p.settle(safeCall(func() (T, error) { return fn(p.report) }))
+	}()
+	return p
+}
+
+func (p *progressPromise[T]) report(u ProgressUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.latest, p.seen = u, true
+	for ch := range p.subs {
+		replaceLatest(ch, u)
+	}
+}
+
+// replaceLatest puts u in ch, a channel with a buffer of one, dropping the
+// update it held if the receiver has not taken it yet.
+func replaceLatest(ch chan ProgressUpdate, u ProgressUpdate) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- u
+}
+
+func (p *progressPromise[T]) Progress() (ProgressUpdate, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.latest, p.seen
+}
+
+func (p *progressPromise[T]) Subscribe(ctx context.Context) <-chan ProgressUpdate {
+	ch := make(chan ProgressUpdate, 1)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seen {
+		ch <- p.latest
+	}
+	if p.Settled() {
+		close(ch)
+		return ch
+	}
+	p.subs[ch] = struct{}{}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-p.done:
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.subs, ch)
+		close(ch)
+	}()
+	return ch
+}
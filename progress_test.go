@@ -0,0 +1,59 @@
+package async
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewPromiseWithProgress(t *testing.T) {
+	ctx := context.Background()
+	step := make(chan struct{})
+	p := NewPromiseWithProgress(func(report func(ProgressUpdate)) (string, error) {
+		for _, pct := range []float64{25, 50, 100} {
+			<-step
+			report(ProgressUpdate{Percent: pct})
+		}
+		<-step
+		return "uploaded", nil
+	})
+	_, ok := p.Progress()
+	requireEqual(t, false, ok)
+
+	updates := p.Subscribe(ctx)
+	var got []float64
+	for i := 0; i < 3; i++ {
+		step <- struct{}{}
+		got = append(got, (<-updates).Percent)
+	}
+	requireEqual(t, []float64{25, 50, 100}, got)
+	u, ok := p.Progress()
+	requireEqual(t, true, ok)
+	requireEqual(t, float64(100), u.Percent)
+
+	step <- struct{}{}
+	v, err := p.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "uploaded", v)
+	_, open := <-updates
+	requireEqual(t, false, open)
+
+	late := p.Subscribe(ctx)
+	requireEqual(t, float64(100), (<-late).Percent)
+	_, open = <-late
+	requireEqual(t, false, open)
+}
+
+func TestProgressSubscribeCancel(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	defer close(release)
+	p := NewPromiseWithProgress(func(report func(ProgressUpdate)) (int, error) {
+		<-release
+		return 0, nil
+	})
+	cctx, cancel := context.WithCancel(ctx)
+	updates := p.Subscribe(cctx)
+	cancel()
+	_, open := <-updates
+	requireEqual(t, false, open)
+}
@@ -0,0 +1,100 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNoResults is returned by Consensus when no promise resolved within the
+// window.
+var ErrNoResults = errors.New("async: no promise resolved in time")
+
+// Quorum awaits the votes and resolves true as soon as needed of them have
+// voted true, cancelling the awaits of the remaining votes. As soon as so many
+// votes have come back false that needed can no longer be reached, it resolves
+// false. A vote that fails is ignored, which is to say it is treated as
+// though it were a false vote; use QuorumStrict to have failures fail the
+// whole quorum instead.
+func Quorum(ctx context.Context, votes []Promise[bool], needed int) (bool, error) {
+	return quorum(ctx, votes, needed, false)
+}
+
+// QuorumStrict is like Quorum, except that the first vote to fail ends the
+// quorum with that error.
+func QuorumStrict(ctx context.Context, votes []Promise[bool], needed int) (bool, error) {
+	return quorum(ctx, votes, needed, true)
+}
+
+func quorum(ctx context.Context, votes []Promise[bool], needed int, strict bool) (bool, error) {
+	if needed <= 0 {
+		return true, nil
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	results := awaitEach(ctx, votes)
+	yes, remaining := 0, len(votes)
+	for yes+remaining >= needed {
+		r := <-results
+		remaining--
+		if r.err != nil && strict {
+			return false, r.err
+		}
+		if r.err == nil && r.v {
+			yes++
+			if yes >= needed {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Consensus collects the values of the promises for up to window, or until all
+// of them have settled, and returns the value that was resolved most often
+// along with how many promises resolved with it. This is a plurality, not a
+// majority: the most common value wins however few promises agree on it, and
+// a tie goes to the value that was seen first. Failed promises are ignored.
+// Should no promise resolve within the window, ErrNoResults is returned.
+func Consensus[T comparable](ctx context.Context, promises []Promise[T], window time.Duration) (T, int, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, window)
+	defer cancel()
+	counts := map[T]int{}
+	var seen []T
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			continue
+		}
+		if counts[r.v] == 0 {
+			seen = append(seen, r.v)
+		}
+		counts[r.v]++
+	}
+	var winner T
+	best := 0
+	for _, v := range seen {
+		if counts[v] > best {
+			winner, best = v, counts[v]
+		}
+	}
+	if best == 0 {
+		var zerov T
+		return zerov, 0, ErrNoResults
+	}
+	return winner, best, nil
+}
+
+// PercentSuccess is like Some, for n being the fraction pct of the promises,
+// rounded up. pct must be in (0, 1].
+func PercentSuccess[T any](ctx context.Context, promises []Promise[T], pct float64) ([]T, error) {
+	if !(pct > 0 && pct <= 1) {
+		return nil, errors.New("async: success percentage must be in (0, 1]")
+	}
+	// allow for pct not being exactly representable, so that 0.6 of 5 is 3.
+	return Some(ctx, int(math.Ceil(pct*float64(len(promises))-1e-9)), promises)
+}
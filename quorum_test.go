@@ -0,0 +1,91 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQuorum(t *testing.T) {
+	ctx := context.Background()
+	probe := newCancelProbe[bool]()
+	ok, err := Quorum(ctx, []Promise[bool]{Resolve(true), Resolve(true), probe}, 2)
+	requireNoError(t, err)
+	requireEqual(t, true, ok)
+	requireCancelled(t, probe)
+
+	probe = newCancelProbe[bool]()
+	ok, err = Quorum(ctx, []Promise[bool]{
+		Resolve(false),
+		Reject[bool](errors.New("unreachable replica")),
+		probe,
+	}, 2)
+	requireNoError(t, err)
+	requireEqual(t, false, ok)
+	requireCancelled(t, probe)
+
+	doh := errors.New("doh!")
+	_, err = QuorumStrict(ctx, []Promise[bool]{Reject[bool](doh), newCancelProbe[bool]()}, 1)
+	requireEqual(t, doh, err)
+}
+
+func TestConsensus(t *testing.T) {
+	ctx := context.Background()
+	v, n, err := Consensus(ctx, []Promise[string]{
+		Resolve("A"),
+		Reject[string](errors.New("doh!")),
+		Resolve("B"),
+		NewPromise(func() (string, error) {
+			time.Sleep(time.Millisecond * 10)
+			return "A", nil
+		}),
+		newCancelProbe[string](),
+	}, time.Millisecond*50)
+	requireNoError(t, err)
+	requireEqual(t, "A", v)
+	requireEqual(t, 2, n)
+
+	_, _, err = Consensus(ctx, []Promise[string]{newCancelProbe[string]()}, time.Millisecond*10)
+	requireEqual(t, ErrNoResults, err)
+}
+
+func TestPercentSuccess(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	probe := newCancelProbe[int]()
+	v, err := PercentSuccess(ctx, []Promise[int]{
+		Resolve(1),
+		Reject[int](doh),
+		Resolve(2),
+		Resolve(3),
+		probe,
+	}, 0.6)
+	requireNoError(t, err)
+	requireEqual(t, 3, len(v))
+	requireCancelled(t, probe)
+
+	probe = newCancelProbe[int]()
+	lateFailure := func() Promise[int] {
+		return NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 10)
+			return 0, doh
+		})
+	}
+	v, err = PercentSuccess(ctx, []Promise[int]{
+		Resolve(1),
+		lateFailure(),
+		lateFailure(),
+		lateFailure(),
+		probe,
+	}, 0.6)
+	requireEqual(t, true, errors.Is(err, ErrTooFewResults))
+	requireEqual(t, true, errors.Is(err, doh))
+	requireEqual(t, []int{1}, v)
+	requireCancelled(t, probe)
+
+	_, err = PercentSuccess(ctx, []Promise[int]{Resolve(1)}, 0)
+	requireError(t, err)
+	_, err = PercentSuccess(ctx, []Promise[int]{Resolve(1)}, 1.5)
+	requireError(t, err)
+}
@@ -0,0 +1,158 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNoPromises is returned by combinators that cannot produce a result from
+// an empty set of promises.
+var ErrNoPromises = errors.New("async: no promises given")
+
+// ErrNoMatch is returned by RaceMatch when every promise settled without
+// producing a value accepted by the match function.
+var ErrNoMatch = errors.New("async: no promise produced a matching value")
+
+// Race returns the outcome, whether a value or an error, of whichever of the
+// promises settles first, and stops waiting on the rest. An empty slice of
+// promises returns ErrNoPromises rather than blocking forever, and if ctx is
+// cancelled before any promise settles, the context error is returned.
+func Race[T any](ctx context.Context, promises []Promise[T]) (T, error) {
+	if len(promises) == 0 {
+		var zerov T
+		return zerov, ErrNoPromises
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	r := <-awaitEach(ctx, promises)
+	return r.v, r.err
+}
+
+// AggregateError is the error returned when every one of a group of promises
+// failed. Errors holds one IndexedError for each promise, in input order, and
+// errors.Is and errors.As see through to all of them.
+type AggregateError struct {
+	Errors []error
+}
+
+func (e *AggregateError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("async: all %d promises failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *AggregateError) Unwrap() []error { return e.Errors }
+
+// Any returns the value of whichever of the promises resolves first, and stops
+// waiting on the rest. Failures are ignored unless every promise fails, in
+// which case an *AggregateError holding all of their errors is returned. An
+// empty slice of promises returns ErrNoPromises.
+func Any[T any](ctx context.Context, promises []Promise[T]) (T, error) {
+	var zerov T
+	if len(promises) == 0 {
+		return zerov, ErrNoPromises
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	errs := make([]error, len(promises))
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err == nil {
+			return r.v, nil
+		}
+		errs[r.index] = IndexedError{Index: r.index, Err: r.err}
+	}
+	return zerov, &AggregateError{Errors: errs}
+}
+
+// RaceMatch awaits all of the promises and returns the first successful value
+// for which match returns true, cancelling the awaits of the remaining
+// promises. Failures and non-matching values are ignored until a match is
+// found. If every promise settles without a match, ErrNoMatch is returned,
+// joined with any errors the promises produced.
+func RaceMatch[T any](ctx context.Context, promises []Promise[T], match func(T) bool) (T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	results := awaitEach(ctx, promises)
+	errs := []error{ErrNoMatch}
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		if match(r.v) {
+			return r.v, nil
+		}
+	}
+	var zerov T
+	return zerov, errors.Join(errs...)
+}
+
+// Elect starts all of the candidates concurrently, and the first to complete
+// successfully becomes the leader: its index and value are returned, and the
+// context given to every other candidate is cancelled so that their work may
+// stop mid-flight. If every candidate fails, the index is -1 and the joined
+// errors are returned.
+func Elect[T any](ctx context.Context, candidates []func(context.Context) (T, error)) (int, T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan outcome[T], len(candidates))
+	for i, candidate := range candidates {
+		go func(i int, candidate func(context.Context) (T, error)) {
+			v, err := safeCall(func() (T, error) { return candidate(ctx) })
+			results <- outcome[T]{index: i, v: v, err: err}
+		}(i, candidate)
+	}
+	errs := make([]error, 0, len(candidates))
+	for range candidates {
+		r := <-results
+		if r.err == nil {
+			return r.index, r.v, nil
+		}
+		errs = append(errs, r.err)
+	}
+	var zerov T
+	return -1, zerov, errors.Join(errs...)
+}
+
+// RaceEntry is a contestant in RaceBudget: a function, and how long it is
+// allowed to take.
+type RaceEntry[T any] struct {
+	Fn func(context.Context) (T, error)
+
+	// Budget is how long Fn is given before it is eliminated from the race. If
+	// not positive, Fn is only bound by the context of the race itself.
+	Budget time.Duration
+}
+
+// RaceBudget races the entries against each other, each one with its own time
+// budget. An entry that exceeds its budget is eliminated without ending the
+// race for the others. The first entry to succeed within its budget wins, and
+// the contexts of the remaining entries are cancelled. If every entry fails or
+// is eliminated, their errors are returned joined together.
+func RaceBudget[T any](ctx context.Context, entries []RaceEntry[T]) (T, error) {
+	candidates := make([]func(context.Context) (T, error), len(entries))
+	for i, entry := range entries {
+		candidates[i] = func(ctx context.Context) (T, error) {
+			if entry.Budget > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, entry.Budget)
+				defer cancel()
+			}
+			return entry.Fn(ctx)
+		}
+	}
+	_, v, err := Elect(ctx, candidates)
+	return v, err
+}
@@ -0,0 +1,151 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRaceMatch(t *testing.T) {
+	ctx := context.Background()
+	even := func(i int) bool { return i%2 == 0 }
+	v, err := RaceMatch(ctx, []Promise[int]{
+		Resolve(1),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 2, nil
+		}),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Second)
+			return 4, nil
+		}),
+	}, even)
+	requireNoError(t, err)
+	requireEqual(t, 2, v)
+
+	doh := errors.New("doh!")
+	_, err = RaceMatch(ctx, []Promise[int]{Resolve(1), Reject[int](doh)}, even)
+	requireEqual(t, true, errors.Is(err, ErrNoMatch))
+	requireEqual(t, true, errors.Is(err, doh))
+}
+
+func TestElect(t *testing.T) {
+	ctx := context.Background()
+	cancelled := make(chan int, 2)
+	loser := func(i int) func(context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			cancelled <- i
+			return "", ctx.Err()
+		}
+	}
+	i, v, err := Elect(ctx, []func(context.Context) (string, error){
+		loser(0),
+		func(ctx context.Context) (string, error) {
+			time.Sleep(time.Millisecond * 10)
+			return "leader", nil
+		},
+		loser(2),
+	})
+	requireNoError(t, err)
+	requireEqual(t, 1, i)
+	requireEqual(t, "leader", v)
+	for n := 0; n < 2; n++ {
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("losing candidates were not cancelled")
+		}
+	}
+
+	i, _, err = Elect(ctx, []func(context.Context) (string, error){
+		func(context.Context) (string, error) { return "", errors.New("doh!") },
+	})
+	requireError(t, err)
+	requireEqual(t, -1, i)
+}
+
+func TestRaceBudget(t *testing.T) {
+	ctx := context.Background()
+	sleepFor := func(d time.Duration, v string) func(context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(d):
+				return v, nil
+			}
+		}
+	}
+	v, err := RaceBudget(ctx, []RaceEntry[string]{
+		{Fn: sleepFor(time.Millisecond*30, "fast backend"), Budget: time.Millisecond * 20},
+		{Fn: sleepFor(time.Millisecond*60, "slow backend"), Budget: time.Millisecond * 200},
+	})
+	requireNoError(t, err)
+	requireEqual(t, "slow backend", v)
+
+	_, err = RaceBudget(ctx, []RaceEntry[string]{
+		{Fn: sleepFor(time.Second, "too slow"), Budget: time.Millisecond * 10},
+		{Fn: func(context.Context) (string, error) { return "", errors.New("doh!") }},
+	})
+	requireEqual(t, true, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestRace(t *testing.T) {
+	ctx := context.Background()
+	loser := newCancelProbe[string]()
+	v, err := Race(ctx, []Promise[string]{
+		loser,
+		NewPromise(func() (string, error) {
+			time.Sleep(time.Millisecond * 10)
+			return "replica b", nil
+		}),
+	})
+	requireNoError(t, err)
+	requireEqual(t, "replica b", v)
+	requireCancelled(t, loser)
+
+	doh := errors.New("doh!")
+	_, err = Race(ctx, []Promise[string]{newCancelProbe[string](), Reject[string](doh)})
+	requireEqual(t, doh, err)
+
+	_, err = Race[string](ctx, nil)
+	requireEqual(t, ErrNoPromises, err)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = Race[string](cctx, []Promise[string]{newCancelProbe[string]()})
+	requireEqual(t, context.Canceled, err)
+}
+
+func TestAny(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	probe := newCancelProbe[string]()
+	v, err := Any(ctx, []Promise[string]{
+		Reject[string](doh),
+		NewPromise(func() (string, error) {
+			time.Sleep(time.Millisecond * 10)
+			return "replica 1", nil
+		}),
+		probe,
+	})
+	requireNoError(t, err)
+	requireEqual(t, "replica 1", v)
+	requireCancelled(t, probe)
+
+	darn := errors.New("darn")
+	_, err = Any(ctx, []Promise[string]{Reject[string](doh), Reject[string](darn)})
+	var aggErr *AggregateError
+	requireEqual(t, true, errors.As(err, &aggErr))
+	requireEqual(t, []error{
+		IndexedError{Index: 0, Err: doh},
+		IndexedError{Index: 1, Err: darn},
+	}, aggErr.Errors)
+	requireEqual(t, true, errors.Is(err, darn))
+	requireEqual(t, "async: all 2 promises failed: promise 0: doh!; promise 1: darn", err.Error())
+
+	_, err = Any[string](ctx, nil)
+	requireEqual(t, ErrNoPromises, err)
+}
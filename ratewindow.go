@@ -0,0 +1,48 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// AllRateWindows awaits all of the promises like All, but paces how quickly
+// their results are collected: at most perWindow results are taken within any
+// one window, after which collection pauses until the window has elapsed since
+// it began. Windows begin when the first result of the window is taken.
+//
+// The promises are very likely already running, so this does not limit how
+// fast the work itself happens, only how fast its results are consumed; it is
+// meant for when processing the results is the bottleneck. The results are
+// returned in input order, and the first error collected short-circuits,
+// cancelling the remaining awaits.
+func AllRateWindows[T any](ctx context.Context, promises []Promise[T], perWindow int, window time.Duration) ([]T, error) {
+	if perWindow <= 0 {
+		return nil, errors.New("async: perWindow must be positive")
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	out := make([]T, len(promises))
+	results := awaitEach(ctx, promises)
+	var windowStart time.Time
+	taken := 0
+	for range promises {
+		if taken == perWindow {
+			if err := sleep(ctx, time.Until(windowStart.Add(window))); err != nil {
+				return nil, err
+			}
+			taken = 0
+		}
+		r := <-results
+		if taken == 0 {
+			windowStart = time.Now()
+		}
+		taken++
+		if r.err != nil {
+			return nil, r.err
+		}
+		out[r.index] = r.v
+	}
+	return out, nil
+}
@@ -0,0 +1,27 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAllRateWindows(t *testing.T) {
+	ctx := context.Background()
+	promises := make([]Promise[int], 6)
+	for i := range promises {
+		promises[i] = Resolve(i)
+	}
+	window := time.Millisecond * 30
+	start := time.Now()
+	v, err := AllRateWindows(ctx, promises, 2, window)
+	requireNoError(t, err)
+	requireEqual(t, []int{0, 1, 2, 3, 4, 5}, v)
+	// three windows worth of results means waiting out at least two windows.
+	if elapsed := time.Since(start); elapsed < window*2 {
+		t.Fatalf("collected 6 results at 2 per %s in only %s", window, elapsed)
+	}
+
+	_, err = AllRateWindows(ctx, promises, 0, window)
+	requireError(t, err)
+}
@@ -0,0 +1,75 @@
+package async
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Recorder captures the outcomes of named promises created with
+// RecordedPromise, so that a run against real dependencies may later be
+// replayed deterministically. A Recorder is either recording, as made by
+// NewRecorder, or replaying, as made by NewReplayer. Outcomes are stored in
+// the same form as MarshalResult, so values must survive a round trip through
+// encoding/json, and errors are restored only as their message.
+type Recorder struct {
+	mu       sync.Mutex
+	replay   bool
+	outcomes map[string]json.RawMessage
+}
+
+// NewRecorder creates a Recorder that records outcomes.
+func NewRecorder() *Recorder {
+	return &Recorder{outcomes: map[string]json.RawMessage{}}
+}
+
+// NewReplayer creates a Recorder that replays the outcomes in log, as produced
+// by the Log method of a recording Recorder.
+func NewReplayer(log []byte) (*Recorder, error) {
+	r := &Recorder{replay: true}
+	if err := json.Unmarshal(log, &r.outcomes); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Log serializes the outcomes recorded so far. Promises that have not yet
+// settled are not included.
+func (r *Recorder) Log() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return json.Marshal(r.outcomes)
+}
+
+// RecordedPromise behaves like NewPromise while rec is recording, storing the
+// outcome of fn under name once it settles; names should be unique within a
+// run. While rec is replaying, fn is never called, and the promise is instead
+// settled with the outcome recorded under name, or rejected should there be
+// none.
+func RecordedPromise[T any](rec *Recorder, name string, fn func() (T, error)) Promise[T] {
+	if rec.replay {
+		rec.mu.Lock()
+		data, ok := rec.outcomes[name]
+		rec.mu.Unlock()
+		if !ok {
+			return Reject[T](fmt.Errorf("async: no recorded outcome for %q", name))
+		}
+		p, err := UnmarshalResult[T](data)
+		if err != nil {
+			return Reject[T](err)
+		}
+		return p
+	}
+	return NewPromise(func() (T, error) {
+		v, err := safeCall(fn)
+		data, merr := MarshalResult(&rp[T]{v: v, err: err})
+		if merr != nil {
+			var zerov T
+			return zerov, fmt.Errorf("async: recording outcome of %q: %w", name, merr)
+		}
+		rec.mu.Lock()
+		rec.outcomes[name] = data
+		rec.mu.Unlock()
+		return v, err
+	})
+}
@@ -0,0 +1,43 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecordedPromise(t *testing.T) {
+	ctx := context.Background()
+	type user struct {
+		ID   int
+		Name string
+	}
+	rec := NewRecorder()
+	u, err := RecordedPromise(rec, "user", func() (user, error) {
+		return user{ID: 1, Name: "nick"}, nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	_, recordedErr := RecordedPromise(rec, "flags", func() (bool, error) {
+		return false, errors.New("flag service down")
+	}).Await(ctx)
+	requireError(t, recordedErr)
+	log, err := rec.Log()
+	requireNoError(t, err)
+
+	rep, err := NewReplayer(log)
+	requireNoError(t, err)
+	replayed, err := RecordedPromise(rep, "user", func() (user, error) {
+		t.Error("fn should not be called while replaying")
+		return user{}, nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, u, replayed)
+	_, err = RecordedPromise(rep, "flags", func() (bool, error) {
+		t.Error("fn should not be called while replaying")
+		return true, nil
+	}).Await(ctx)
+	requireEqual(t, recordedErr.Error(), err.Error())
+
+	_, err = RecordedPromise(rep, "missing", func() (int, error) { return 0, nil }).Await(ctx)
+	requireError(t, err)
+}
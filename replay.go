@@ -0,0 +1,29 @@
+package async
+
+import (
+	"sync"
+)
+
+// Replay shares the result of p with every promise handed out by get,
+// including those requested long after p has settled. Calling reset swaps the
+// underlying promise for next, so that subsequent calls to get will await next
+// instead, while promises obtained before the reset continue to report the
+// original result. This gives a simple cache-with-invalidation primitive.
+// reset takes the promise to replay from then on, rather than swapping in a
+// bare pending promise, because Replay would otherwise have no way of learning
+// the result that the later callers of get are waiting for.
+func Replay[T any](p Promise[T]) (get func() Promise[T], reset func(next Promise[T])) {
+	var mu sync.Mutex
+	current := p
+	get = func() Promise[T] {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+	reset = func(next Promise[T]) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = next
+	}
+	return get, reset
+}
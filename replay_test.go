@@ -0,0 +1,32 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	get, reset := Replay(Resolve("old"))
+	before := get()
+
+	reset(NewPromise(func() (string, error) {
+		time.Sleep(time.Millisecond * 20)
+		return "new", nil
+	}))
+	after := get()
+	requireEqual(t, false, after.Settled())
+
+	v, err := before.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "old", v)
+
+	v, err = after.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "new", v)
+
+	v, err = get().Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "new", v)
+}
@@ -0,0 +1,24 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// AwaitResilient awaits p using a context obtained from ctxFactory. If that
+// await is interrupted by its context (and p has not actually settled), p is
+// awaited again with a fresh context from ctxFactory, up to maxRetries more
+// times. Since a promise may be awaited any number of times, this only retries
+// the wait and never the work behind p.
+func AwaitResilient[T any](ctxFactory func() context.Context, p Promise[T], maxRetries int) (T, error) {
+	for attempt := 0; ; attempt++ {
+		v, err := p.Await(ctxFactory())
+		if err == nil || attempt >= maxRetries || p.Settled() || !isContextError(err) {
+			return v, err
+		}
+	}
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,34 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAwaitResilient(t *testing.T) {
+	promise := NewPromise(func() (string, error) {
+		time.Sleep(time.Millisecond * 50)
+		return "eventually", nil
+	})
+	timeouts := []time.Duration{time.Millisecond * 5, time.Second}
+	calls := 0
+	factory := func() context.Context {
+		ctx, cancel := context.WithTimeout(context.Background(), timeouts[calls])
+		t.Cleanup(cancel)
+		calls++
+		return ctx
+	}
+	v, err := AwaitResilient(factory, promise, 1)
+	requireNoError(t, err)
+	requireEqual(t, "eventually", v)
+	requireEqual(t, 2, calls)
+
+	calls = 0
+	_, err = AwaitResilient(factory, NewPromise(func() (string, error) {
+		time.Sleep(time.Millisecond * 50)
+		return "too slow", nil
+	}), 0)
+	requireEqual(t, context.DeadlineExceeded, err)
+	requireEqual(t, 1, calls)
+}
@@ -0,0 +1,61 @@
+package async
+
+import (
+	"context"
+	"fmt"
+)
+
+// Result holds the outcome of awaiting a promise as a single value, for
+// callers that prefer that style over handling a (T, error) pair.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok creates a successful Result.
+func Ok[T any](v T) Result[T] { return Result[T]{value: v} }
+
+// Err creates a failed Result.
+func Err[T any](err error) Result[T] { return Result[T]{err: err} }
+
+// Try awaits p and captures its outcome as a Result.
+func Try[T any](ctx context.Context, p Promise[T]) Result[T] {
+	v, err := p.Await(ctx)
+	return Result[T]{value: v, err: err}
+}
+
+// IsOk reports whether the result is successful.
+func (r Result[T]) IsOk() bool { return r.err == nil }
+
+// Err returns the error of a failed result, or nil for a successful one.
+func (r Result[T]) Err() error { return r.err }
+
+// Unwrap returns the value of a successful result, and panics if the result
+// failed.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("async: unwrap of failed result: %s", r.err.Error()))
+	}
+	return r.value
+}
+
+// UnwrapOr returns the value of a successful result, or def if it failed.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// Get returns the value and error of the result as a pair.
+func (r Result[T]) Get() (T, error) { return r.value, r.err }
+
+// MapResult transforms the value of a successful result with fn. A failed
+// result is passed through without calling fn.
+func MapResult[T, U any](r Result[T], fn func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+	u, err := fn(r.value)
+	return Result[U]{value: u, err: err}
+}
@@ -0,0 +1,35 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestTry(t *testing.T) {
+	ctx := context.Background()
+	ok := Try(ctx, Resolve(7))
+	requireEqual(t, true, ok.IsOk())
+	requireNoError(t, ok.Err())
+	requireEqual(t, 7, ok.Unwrap())
+	requireEqual(t, 7, ok.UnwrapOr(-1))
+
+	failed := Try(ctx, Reject[int](errors.New("doh!")))
+	requireEqual(t, false, failed.IsOk())
+	requireError(t, failed.Err())
+	requireEqual(t, -1, failed.UnwrapOr(-1))
+
+	s := MapResult(ok, func(v int) (string, error) { return strconv.Itoa(v), nil })
+	requireEqual(t, "7", s.Unwrap())
+	s = MapResult(failed, func(v int) (string, error) {
+		t.Error("fn should not be called for a failed result")
+		return "", nil
+	})
+	requireEqual(t, failed.Err(), s.Err())
+
+	defer func() {
+		requireEqual(t, true, recover() != nil)
+	}()
+	failed.Unwrap()
+}
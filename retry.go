@@ -0,0 +1,189 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryError is the error a retrying promise is rejected with once it has
+// given up. It records how many attempts were made, and unwraps to the last
+// error encountered. Errors holds an AttemptError for each failed attempt, in
+// order, for callers that want to inspect the earlier failures too.
+type RetryError struct {
+	Attempts int
+	Err      error
+	Errors   []error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempt(s): %s", e.Attempts, e.Err.Error())
+}
+
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// AttemptError is the error from one attempt of a retrying promise, tagged with
+// the number of the attempt, counting from 1.
+type AttemptError struct {
+	Attempt int
+	Err     error
+}
+
+func (e AttemptError) Error() string {
+	return fmt.Sprintf("attempt %d: %s", e.Attempt, e.Err.Error())
+}
+
+func (e AttemptError) Unwrap() error { return e.Err }
+
+// RetryPolicy describes how RetryWithPolicy should go about retrying a
+// function.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the function will be called.
+	// It must be positive.
+	MaxAttempts int
+
+	// Deadline, if positive, is the total time budget for all attempts
+	// (including time spent backing off). Once it elapses, no further attempts
+	// are made and the context given to the function is cancelled.
+	Deadline time.Duration
+
+	// Backoff returns how long to wait after the given failed attempt (counting
+	// from 1) before trying again. If nil, attempts are retried immediately.
+	Backoff func(attempt int) time.Duration
+
+	// Jitter randomly shortens each backoff by up to this fraction of its
+	// length, so that many callers retrying at once do not do so in lockstep.
+	// It must be between 0 (no jitter) and 1 ("full" jitter).
+	Jitter float64
+
+	// RetryIf reports whether an error should be retried. If nil, every error
+	// is retried.
+	RetryIf func(error) bool
+}
+
+// ExponentialBackoff returns a backoff function for RetryPolicy that waits base
+// after the first failed attempt, and twice as long after each one after that,
+// up to maxDelay. A maxDelay that is not positive leaves the backoff uncapped.
+func ExponentialBackoff(base, maxDelay time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d <= math.MaxInt64/2 && (maxDelay <= 0 || d < maxDelay); i++ {
+			d *= 2
+		}
+		if maxDelay > 0 && d > maxDelay {
+			return maxDelay
+		}
+		return d
+	}
+}
+
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts <= 0 {
+		return errors.New("async: retry policy must allow at least one attempt")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return errors.New("async: retry policy jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.Backoff == nil {
+		return 0
+	}
+	d := p.Backoff(attempt)
+	if p.Jitter > 0 && d > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// RetryWithPolicy runs fn in a new goroutine, retrying it according to policy
+// until it succeeds. The promise resolves with the first successful result, or
+// rejects with a *RetryError wrapping the last error once the policy gives up.
+// If ctx is cancelled or the policy deadline elapses while backing off, the
+// *RetryError wraps the context error instead. An invalid policy rejects the
+// promise without calling fn.
+func RetryWithPolicy[T any](ctx context.Context, policy RetryPolicy, fn func(context.Context) (T, error)) Promise[T] {
+	return NewPromise(func() (T, error) {
+		var zerov T
+		if err := policy.validate(); err != nil {
+			return zerov, err
+		}
+		if policy.Deadline > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = withClockTimeout(ctx, policy.Deadline)
+			defer cancel()
+		}
+		var errs []error
+		for attempt := 1; ; attempt++ {
+			v, err := fn(ctx)
+			if err == nil {
+				return v, nil
+			}
+			errs = append(errs, AttemptError{Attempt: attempt, Err: err})
+			if attempt >= policy.MaxAttempts || (policy.RetryIf != nil && !policy.RetryIf(err)) {
+				return zerov, &RetryError{Attempts: attempt, Err: err, Errors: errs}
+			}
+			if err := sleep(ctx, policy.backoff(attempt)); err != nil {
+				return zerov, &RetryError{Attempts: attempt, Err: err, Errors: errs}
+			}
+		}
+	})
+}
+
+// Retry runs fn in a new goroutine, calling it up to attempts times until it
+// succeeds, and waiting backoff(n) after the n-th failed attempt before trying
+// again. A nil backoff retries immediately. An error that implements
+// Retryable() bool, and reports false, is not retried. Once Retry gives up, or
+// ctx is cancelled while it is backing off, the promise rejects with a
+// *RetryError wrapping the last error encountered. Retry is shorthand for
+// RetryWithPolicy with a policy built from its arguments.
+func Retry[T any](ctx context.Context, attempts int, backoff func(attempt int) time.Duration, fn func(context.Context) (T, error)) Promise[T] {
+	return RetryWithPolicy(ctx, RetryPolicy{
+		MaxAttempts: attempts,
+		Backoff:     backoff,
+		RetryIf:     retryable,
+	}, fn)
+}
+
+// RetryPromise is like Retry, for work that comes as a promise rather than a
+// function: each attempt awaits a fresh promise from factory, so that a
+// rejected promise is never awaited again.
+func RetryPromise[T any](ctx context.Context, attempts int, factory func() Promise[T], backoff func(attempt int) time.Duration) Promise[T] {
+	return Retry(ctx, attempts, backoff, func(ctx context.Context) (T, error) {
+		return factory().Await(ctx)
+	})
+}
+
+// retryable reports whether err should be retried, which is the case unless
+// something in its chain implements Retryable() bool and reports false.
+func retryable(err error) bool {
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+	return true
+}
+
+// sleep pauses for d, returning early with the context error should ctx be
+// cancelled first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+	timer := currentClock().NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryWithPolicy(t *testing.T) {
+	ctx := context.Background()
+	flaky := errors.New("flaky")
+	calls := 0
+	v, err := RetryWithPolicy(ctx, RetryPolicy{MaxAttempts: 3}, func(context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, flaky
+		}
+		return calls, nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 3, v)
+
+	calls = 0
+	_, err = RetryWithPolicy(ctx, RetryPolicy{
+		MaxAttempts: 100,
+		Deadline:    time.Millisecond * 50,
+		Backoff:     func(int) time.Duration { return time.Millisecond * 20 },
+		Jitter:      0.5,
+	}, func(context.Context) (int, error) {
+		calls++
+		return 0, flaky
+	}).Await(ctx)
+	requireEqual(t, true, errors.Is(err, context.DeadlineExceeded))
+	if calls < 2 || calls >= 100 {
+		t.Fatalf("expected the deadline to cut retries short, got %d calls", calls)
+	}
+
+	calls = 0
+	permanent := errors.New("permanent")
+	_, err = RetryWithPolicy(ctx, RetryPolicy{
+		MaxAttempts: 5,
+		RetryIf:     func(err error) bool { return err != permanent },
+	}, func(context.Context) (int, error) {
+		calls++
+		return 0, permanent
+	}).Await(ctx)
+	requireEqual(t, true, errors.Is(err, permanent))
+	requireEqual(t, 1, calls)
+
+	_, err = RetryWithPolicy(ctx, RetryPolicy{}, func(context.Context) (int, error) {
+		t.Error("fn should not be called with an invalid policy")
+		return 0, nil
+	}).Await(ctx)
+	requireError(t, err)
+}
+
+func TestRetryError(t *testing.T) {
+	ctx := context.Background()
+	flaky := errors.New("flaky")
+	first := errors.New("first")
+	calls := 0
+	_, err := RetryWithPolicy(ctx, RetryPolicy{MaxAttempts: 3}, func(context.Context) (int, error) {
+		if calls++; calls == 1 {
+			return 0, first
+		}
+		return 0, flaky
+	}).Await(ctx)
+	var retryErr *RetryError
+	requireEqual(t, true, errors.As(err, &retryErr))
+	requireEqual(t, 3, retryErr.Attempts)
+	requireEqual(t, 3, len(retryErr.Errors))
+	requireEqual(t, true, errors.Is(err, flaky))
+	requireEqual(t, false, errors.Is(err, first))
+	requireEqual(t, true, errors.Is(retryErr.Errors[0], first))
+	var attemptErr AttemptError
+	requireEqual(t, true, errors.As(retryErr.Errors[0], &attemptErr))
+	requireEqual(t, AttemptError{Attempt: 1, Err: first}, attemptErr)
+}
+
+type permanentError struct{}
+
+func (permanentError) Error() string   { return "permanent" }
+func (permanentError) Retryable() bool { return false }
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	flaky := errors.New("flaky")
+	var backoffs []int
+	calls := 0
+	v, err := Retry(ctx, 3, func(attempt int) time.Duration {
+		backoffs = append(backoffs, attempt)
+		return time.Millisecond
+	}, func(context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, flaky
+		}
+		return calls, nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 3, v)
+	requireEqual(t, []int{1, 2}, backoffs)
+
+	calls = 0
+	_, err = Retry(ctx, 5, nil, func(context.Context) (int, error) {
+		calls++
+		return 0, fmt.Errorf("wrapped: %w", permanentError{})
+	}).Await(ctx)
+	requireEqual(t, true, errors.Is(err, permanentError{}))
+	requireEqual(t, 1, calls)
+
+	cctx, cancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer cancel()
+	start := time.Now()
+	_, err = Retry(cctx, 5, func(int) time.Duration { return time.Hour }, func(context.Context) (int, error) {
+		return 0, flaky
+	}).Await(ctx)
+	requireEqual(t, true, errors.Is(err, context.DeadlineExceeded))
+	if time.Since(start) > time.Second {
+		t.Fatal("expected the backoff to be cut short by the context")
+	}
+}
+
+func TestRetryPromise(t *testing.T) {
+	ctx := context.Background()
+	flaky := errors.New("flaky")
+	calls := 0
+	v, err := RetryPromise(ctx, 5, func() Promise[int] {
+		calls++
+		if calls < 3 {
+			return Reject[int](flaky)
+		}
+		return Resolve(calls * 10)
+	}, nil).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 30, v)
+	requireEqual(t, 3, calls)
+
+	calls = 0
+	_, err = RetryPromise(ctx, 2, func() Promise[int] {
+		calls++
+		return Reject[int](flaky)
+	}, func(int) time.Duration { return time.Millisecond }).Await(ctx)
+	requireEqual(t, true, errors.Is(err, flaky))
+	requireEqual(t, 2, calls)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Millisecond*100, time.Second)
+	var got []time.Duration
+	for attempt := 1; attempt <= 6; attempt++ {
+		got = append(got, backoff(attempt))
+	}
+	requireEqual(t, []time.Duration{
+		time.Millisecond * 100,
+		time.Millisecond * 200,
+		time.Millisecond * 400,
+		time.Millisecond * 800,
+		time.Second,
+		time.Second,
+	}, got)
+	requireEqual(t, time.Second, backoff(1000))
+	requireEqual(t, time.Millisecond*800, ExponentialBackoff(time.Millisecond*100, 0)(4))
+}
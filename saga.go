@@ -0,0 +1,54 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Saga runs a sequence of steps, each of which may be undone by a compensating
+// action should a later step fail, in the manner of the saga pattern for
+// distributed transactions. The zero value is ready to use.
+type Saga struct {
+	mu    sync.Mutex
+	steps []sagaStep
+}
+
+type sagaStep struct {
+	forward    func(context.Context) error
+	compensate func(context.Context) error
+}
+
+// Step registers a step of the saga. compensate is run to undo forward should
+// a later step fail, and may be nil if there is nothing to undo.
+func (s *Saga) Step(forward func(ctx context.Context) error, compensate func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, sagaStep{forward: forward, compensate: compensate})
+}
+
+// Run runs the forward action of every step, one at a time, in the order that
+// they were registered. Should one of them fail, or ctx be cancelled between
+// steps, the compensating actions of the steps that completed are run in
+// reverse order, like a DeferStack, and the error is returned joined with any
+// errors from compensating. Compensating actions are given ctx without its
+// cancellation, so that a cancelled run can still be undone.
+func (s *Saga) Run(ctx context.Context) error {
+	s.mu.Lock()
+	steps := s.steps
+	s.mu.Unlock()
+	var undo DeferStack
+	for _, step := range steps {
+		err := ctx.Err()
+		if err == nil {
+			err = step.forward(ctx)
+		}
+		if err != nil {
+			return errors.Join(err, undo.Run(context.WithoutCancel(ctx)))
+		}
+		if step.compensate != nil {
+			undo.Push(step.compensate)
+		}
+	}
+	return nil
+}
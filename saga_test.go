@@ -0,0 +1,37 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSaga(t *testing.T) {
+	ctx := context.Background()
+	var log []string
+	step := func(name string, err error) (func(context.Context) error, func(context.Context) error) {
+		return func(context.Context) error {
+				log = append(log, "do "+name)
+				return err
+			}, func(context.Context) error {
+				log = append(log, "undo "+name)
+				return nil
+			}
+	}
+	doh := errors.New("doh!")
+	var saga Saga
+	saga.Step(step("1", nil))
+	saga.Step(step("2", nil))
+	saga.Step(step("3", doh))
+	saga.Step(step("4", nil))
+	err := saga.Run(ctx)
+	requireEqual(t, true, errors.Is(err, doh))
+	requireEqual(t, []string{"do 1", "do 2", "do 3", "undo 2", "undo 1"}, log)
+
+	log = nil
+	saga = Saga{}
+	saga.Step(step("1", nil))
+	saga.Step(step("2", nil))
+	requireNoError(t, saga.Run(ctx))
+	requireEqual(t, []string{"do 1", "do 2"}, log)
+}
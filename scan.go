@@ -0,0 +1,64 @@
+package async
+
+import (
+	"context"
+	"iter"
+)
+
+// Scan returns a sequence that folds the values of the promises, in the order
+// that they settle, into an accumulator starting from init, yielding the
+// running accumulator after each step. Nothing is awaited until the sequence
+// is ranged over. Should a promise fail, its error is yielded alongside the
+// accumulator as it stood, and the sequence ends. Breaking out of the range
+// early cancels the awaits of the remaining promises.
+func Scan[T, A any](ctx context.Context, promises []Promise[T], init A, fn func(A, T) A) iter.Seq2[A, error] {
+	return func(yield func(A, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		acc := init
+		results := awaitEach(ctx, promises)
+		for range promises {
+			r := <-results
+			if r.err != nil {
+				yield(acc, r.err)
+				return
+			}
+			acc = fn(acc, r.v)
+			if !yield(acc, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ReduceWhile folds the values of the promises into an accumulator starting
+// from init, in input order, for as long as fn reports that it wants more. As
+// soon as fn returns false, the accumulator it returned is the result, and the
+// awaits of the remaining promises are cancelled. Promises are awaited
+// concurrently, with values that settle early held back until their turn.
+// Should a promise fail before the fold is done, its error is returned.
+func ReduceWhile[T, A any](ctx context.Context, promises []Promise[T], init A, fn func(A, T) (A, bool)) (A, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	acc := init
+	settled := make([]*outcome[T], len(promises))
+	next := 0
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		settled[r.index] = &r
+		for ; next < len(settled) && settled[next] != nil; next++ {
+			s := settled[next]
+			if s.err != nil {
+				var zerov A
+				return zerov, s.err
+			}
+			var more bool
+			if acc, more = fn(acc, s.v); !more {
+				return acc, nil
+			}
+		}
+	}
+	return acc, nil
+}
@@ -0,0 +1,67 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScan(t *testing.T) {
+	ctx := context.Background()
+	delayed := func(d time.Duration, v int) Promise[int] {
+		return NewPromise(func() (int, error) {
+			time.Sleep(d)
+			return v, nil
+		})
+	}
+	sum := func(a, v int) int { return a + v }
+	var sums []int
+	for acc, err := range Scan(ctx, []Promise[int]{
+		delayed(time.Millisecond*40, 3),
+		delayed(0, 1),
+		delayed(time.Millisecond*20, 2),
+	}, 0, sum) {
+		requireNoError(t, err)
+		sums = append(sums, acc)
+	}
+	requireEqual(t, []int{1, 3, 6}, sums)
+
+	doh := errors.New("doh!")
+	var errs []error
+	for _, err := range Scan(ctx, []Promise[int]{Reject[int](doh), newCancelProbe[int]()}, 0, sum) {
+		errs = append(errs, err)
+	}
+	requireEqual(t, []error{doh}, errs)
+}
+
+func TestReduceWhile(t *testing.T) {
+	ctx := context.Background()
+	probe := newCancelProbe[int]()
+	var folded []int
+	sumUntilFive := func(a, v int) (int, bool) {
+		folded = append(folded, v)
+		return a + v, a+v < 5
+	}
+	v, err := ReduceWhile(ctx, []Promise[int]{
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 2, nil
+		}),
+		Resolve(3),
+		probe,
+	}, 0, sumUntilFive)
+	requireNoError(t, err)
+	requireEqual(t, 5, v)
+	requireEqual(t, []int{2, 3}, folded)
+	requireCancelled(t, probe)
+
+	folded = nil
+	v, err = ReduceWhile(ctx, []Promise[int]{Resolve(1), Resolve(1)}, 0, sumUntilFive)
+	requireNoError(t, err)
+	requireEqual(t, 2, v)
+
+	doh := errors.New("doh!")
+	_, err = ReduceWhile(ctx, []Promise[int]{Resolve(1), Reject[int](doh)}, 0, sumUntilFive)
+	requireEqual(t, doh, err)
+}
@@ -0,0 +1,61 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// Scope gives structured concurrency to promises, in the manner of errgroup:
+// every promise started in a scope with Go shares a context that is cancelled
+// as soon as one of them fails, and Wait does not return until all of them
+// have finished, so that none of their goroutines outlive the scope.
+type Scope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewScope creates a new Scope, whose context is derived from ctx.
+func NewScope(ctx context.Context) *Scope {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Scope{ctx: ctx, cancel: cancel}
+}
+
+// Go runs fn in a new goroutine that belongs to s, like NewPromiseContext,
+// giving it the context of the scope. Should fn fail, the context of the scope
+// is cancelled, and the error is returned by Wait.
+func Go[T any](s *Scope, fn func(context.Context) (T, error)) Promise[T] {
+	p := newSyncPromise[T]()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		v, err := safeCall(func() (T, error) { return fn(s.ctx) })
+		if err != nil {
+			s.fail(err)
+		}
+		p.settle(v, err)
+	}()
+	return p
+}
+
+func (s *Scope) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+		s.cancel()
+	}
+}
+
+// Wait blocks until every promise started in s has settled, then cancels the
+// context of the scope and returns the first error any of them failed with.
+func (s *Scope) Wait() error {
+	s.wg.Wait()
+	s.cancel()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
@@ -0,0 +1,41 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScope(t *testing.T) {
+	ctx := context.Background()
+	s := NewScope(ctx)
+	var finished atomic.Int32
+	a := Go(s, func(context.Context) (int, error) {
+		time.Sleep(time.Millisecond * 20)
+		finished.Add(1)
+		return 1, nil
+	})
+	b := Go(s, func(context.Context) (string, error) {
+		finished.Add(1)
+		return "two", nil
+	})
+	requireNoError(t, s.Wait())
+	requireEqual(t, int32(2), finished.Load())
+	requireEqual(t, true, a.Settled())
+	requireEqual(t, true, b.Settled())
+
+	doh := errors.New("doh!")
+	s = NewScope(ctx)
+	sibling := Go(s, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	Go(s, func(context.Context) (int, error) {
+		return 0, doh
+	})
+	requireEqual(t, doh, s.Wait())
+	_, err := sibling.Await(ctx)
+	requireEqual(t, context.Canceled, err)
+}
@@ -0,0 +1,119 @@
+package async
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Release returns a permit to the Semaphore it was acquired from. Calling a
+// Release more than once has no further effect.
+type Release func()
+
+// Semaphore limits access to a resource to a fixed number of holders at a
+// time. Permits are handed out to waiters in the order that they asked for
+// them.
+type Semaphore struct {
+	mu      sync.Mutex
+	size    int
+	avail   int
+	waiters list.List
+}
+
+type semaphoreWaiter struct {
+	n int
+	p *syncPromise[Release]
+}
+
+// NewSemaphore creates a Semaphore with n permits.
+func NewSemaphore(n int) *Semaphore {
+	return &Semaphore{size: n, avail: n}
+}
+
+// Acquire returns a promise that resolves with a Release once a permit is
+// available. If ctx is cancelled while waiting, the waiter gives up its place
+// in line and the promise is rejected with the context error. Once the promise
+// has resolved, the caller is responsible for calling the Release, even if it
+// has since lost interest in the permit.
+func (s *Semaphore) Acquire(ctx context.Context) Promise[Release] {
+	return s.AcquireN(ctx, 1)
+}
+
+// AcquireN is like Acquire, for n permits at once, which are all returned by
+// the one Release. A waiter for many permits holds up those behind it, rather
+// than being overtaken, so that it is not starved. Asking for fewer than one
+// permit, or more than the Semaphore has, is rejected right away.
+func (s *Semaphore) AcquireN(ctx context.Context, n int) Promise[Release] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n < 1 || n > s.size {
+		return Reject[Release](fmt.Errorf("async: cannot acquire %d of %d permits", n, s.size))
+	}
+	if s.avail >= n && s.waiters.Len() == 0 {
+		s.avail -= n
+		return Resolve(s.releaser(n))
+	}
+	p := newSyncPromise[Release]()
+	elem := s.waiters.PushBack(&semaphoreWaiter{n: n, p: p})
+	go func() {
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if !p.Settled() {
+				s.waiters.Remove(elem)
+				p.settle(nil, ctx.Err())
+				// The waiters behind this one may fit now.
+				s.notify()
+			}
+		}
+	}()
+	return p
+}
+
+// TryAcquire takes a permit without waiting, reporting false if none are
+// available.
+func (s *Semaphore) TryAcquire() (Release, bool) {
+	return s.TryAcquireN(1)
+}
+
+// TryAcquireN is like TryAcquire, for n permits at once.
+func (s *Semaphore) TryAcquireN(n int) (Release, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n >= 1 && s.avail >= n && s.waiters.Len() == 0 {
+		s.avail -= n
+		return s.releaser(n), true
+	}
+	return nil, false
+}
+
+func (s *Semaphore) releaser(n int) Release {
+	var once sync.Once
+	return func() {
+		once.Do(func() { s.release(n) })
+	}
+}
+
+func (s *Semaphore) release(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.avail += n
+	s.notify()
+}
+
+// notify hands out permits to the waiters at the front of the line, for as
+// long as there are enough for the next of them. It is called with s.mu held.
+func (s *Semaphore) notify() {
+	for front := s.waiters.Front(); front != nil; front = s.waiters.Front() {
+		w := front.Value.(*semaphoreWaiter)
+		if s.avail < w.n {
+			return
+		}
+		s.avail -= w.n
+		s.waiters.Remove(front)
+		w.p.settle(s.releaser(w.n), nil)
+	}
+}
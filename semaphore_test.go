@@ -0,0 +1,69 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSemaphore(t *testing.T) {
+	ctx := context.Background()
+	sem := NewSemaphore(2)
+	r1, err := sem.Acquire(ctx).Await(ctx)
+	requireNoError(t, err)
+	r2, ok := sem.TryAcquire()
+	requireEqual(t, true, ok)
+
+	_, ok = sem.TryAcquire()
+	requireEqual(t, false, ok)
+
+	pending := sem.Acquire(ctx)
+	time.Sleep(time.Millisecond * 10)
+	requireEqual(t, false, pending.Settled())
+
+	r1()
+	r1() // releasing twice must not hand out an extra permit
+	r3, err := pending.Await(ctx)
+	requireNoError(t, err)
+	_, ok = sem.TryAcquire()
+	requireEqual(t, false, ok)
+
+	cctx, cancel := context.WithCancel(ctx)
+	abandoned := sem.Acquire(cctx)
+	next := sem.Acquire(ctx)
+	cancel()
+	_, err = abandoned.Await(ctx)
+	requireEqual(t, context.Canceled, err)
+
+	r2()
+	r4, err := next.Await(ctx)
+	requireNoError(t, err)
+	r3()
+	r4()
+	r5, ok := sem.TryAcquire()
+	requireEqual(t, true, ok)
+	r5()
+}
+
+func TestSemaphoreWeighted(t *testing.T) {
+	ctx := context.Background()
+	sem := NewSemaphore(3)
+	two, err := sem.AcquireN(ctx, 2).Await(ctx)
+	requireNoError(t, err)
+
+	heavy := sem.AcquireN(ctx, 3)
+	_, ok := sem.TryAcquire()
+	requireEqual(t, false, ok) // the heavy waiter is not overtaken
+	two()
+	all, err := heavy.Await(ctx)
+	requireNoError(t, err)
+	all()
+
+	_, err = sem.AcquireN(ctx, 4).Await(ctx)
+	requireError(t, err)
+	_, ok = sem.TryAcquireN(0)
+	requireEqual(t, false, ok)
+	r, ok := sem.TryAcquireN(3)
+	requireEqual(t, true, ok)
+	r()
+}
@@ -0,0 +1,189 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTooManyErrors is returned by AllMaxErrors when enough promises fail that
+// the batch is abandoned.
+var ErrTooManyErrors = errors.New("async: too many promises failed")
+
+// Settled is the outcome of a single promise: either the value it resolved
+// with, or the error it was rejected with.
+type Settled[T any] struct {
+	Value T
+	Err   error
+}
+
+// AllSettled awaits all of the promises and returns the outcome of every one
+// of them, in input order. Unlike All, a failure never short-circuits or
+// cancels the others. If ctx is cancelled, promises that are still pending
+// carry the context error.
+func AllSettled[T any](ctx context.Context, promises []Promise[T]) []Settled[T] {
+	out := make([]Settled[T], len(promises))
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		out[r.index] = Settled[T]{Value: r.v, Err: r.err}
+	}
+	return out
+}
+
+// AllSettledDeadline is like AllSettled, but stops waiting after d. Promises
+// that are still pending once d elapses carry context.DeadlineExceeded.
+func AllSettledDeadline[T any](ctx context.Context, d time.Duration, promises []Promise[T]) []Settled[T] {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	return AllSettled(ctx, promises)
+}
+
+// AllCancelable starts awaiting all of the promises right away, but leaves the
+// decision of when to stop waiting to the caller. get blocks until either
+// every promise has settled or cancel is called, and returns the outcome of
+// every promise in input order. If cancel cut the wait short, promises that
+// were still pending carry context.Canceled, and get also returns
+// context.Canceled as the reason; outcomes that were already known are left
+// intact. get may be called any number of times.
+func AllCancelable[T any](promises []Promise[T]) (get func() (results []Settled[T], err error), cancel context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	collected := NewPromise(func() ([]Settled[T], error) {
+		out := make([]Settled[T], len(promises))
+		results := awaitEach(ctx, promises)
+		interrupted := false
+		for range promises {
+			r := <-results
+			out[r.index] = Settled[T]{Value: r.v, Err: r.err}
+			if r.err != nil && ctx.Err() != nil && errors.Is(r.err, ctx.Err()) {
+				interrupted = true
+			}
+		}
+		if interrupted {
+			return out, ctx.Err()
+		}
+		return out, nil
+	})
+	get = func() ([]Settled[T], error) {
+		return collected.Await(context.Background())
+	}
+	return get, cancel
+}
+
+// AllWithDefaults awaits all of the promises without short-circuiting, and
+// returns their values in input order, substituting the entry at the same
+// position in defaults for every promise that failed. defaults must be the same
+// length as promises, otherwise an error is returned before anything is
+// awaited.
+func AllWithDefaults[T any](ctx context.Context, promises []Promise[T], defaults []T) ([]T, error) {
+	if len(promises) != len(defaults) {
+		return nil, fmt.Errorf("async: %d defaults given for %d promises", len(defaults), len(promises))
+	}
+	out := make([]T, len(promises))
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			out[r.index] = defaults[r.index]
+			continue
+		}
+		out[r.index] = r.v
+	}
+	return out, nil
+}
+
+// AllUntilIdle collects the outcome of every promise like AllSettled, but gives
+// up once idle passes without any further promise settling, as a stall is
+// taken to mean the rest are not worth waiting for. The outcomes collected so
+// far are returned in input order, with the promises still pending carrying
+// context.DeadlineExceeded, which is then also returned as the error. If ctx
+// is cancelled first, pending promises carry, and the error is, the context
+// error instead.
+func AllUntilIdle[T any](ctx context.Context, promises []Promise[T], idle time.Duration) ([]Settled[T], error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	out := make([]Settled[T], len(promises))
+	pending := make([]bool, len(promises))
+	for i := range pending {
+		pending[i] = true
+	}
+	results := awaitEach(ctx, promises)
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	giveUp := func(err error) ([]Settled[T], error) {
+		for i := range out {
+			if pending[i] {
+				out[i].Err = err
+			}
+		}
+		return out, err
+	}
+	for range promises {
+		select {
+		case <-ctx.Done():
+			return giveUp(ctx.Err())
+		case <-timer.C:
+			return giveUp(context.DeadlineExceeded)
+		case r := <-results:
+			out[r.index] = Settled[T]{Value: r.v, Err: r.err}
+			pending[r.index] = false
+			timer.Reset(idle)
+		}
+	}
+	return out, nil
+}
+
+// AllMaxErrors collects the outcome of every promise like AllSettled, but
+// abandons the batch as soon as maxErrors of them have failed, cancelling the
+// awaits of the promises that are still pending. In that case the outcomes
+// collected so far are returned in input order, with the pending promises
+// carrying context.Canceled, along with ErrTooManyErrors joined with the errors
+// of the failed promises. A maxErrors below 1 abandons the batch on the first
+// failure.
+func AllMaxErrors[T any](ctx context.Context, maxErrors int, promises []Promise[T]) ([]Settled[T], error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	out := make([]Settled[T], len(promises))
+	pending := make([]bool, len(promises))
+	for i := range pending {
+		pending[i] = true
+	}
+	errs := []error{ErrTooManyErrors}
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		out[r.index] = Settled[T]{Value: r.v, Err: r.err}
+		pending[r.index] = false
+		if r.err == nil {
+			continue
+		}
+		errs = append(errs, r.err)
+		if len(errs)-1 >= maxErrors {
+			for i := range out {
+				if pending[i] {
+					out[i].Err = context.Canceled
+				}
+			}
+			return out, errors.Join(errs...)
+		}
+	}
+	return out, nil
+}
+
+// AllIndexedSettled is like AllSettled, but sorts the outcomes into the values
+// of the promises that resolved and the errors of those that failed, each
+// keyed by the position of the promise in the input.
+func AllIndexedSettled[T any](ctx context.Context, promises []Promise[T]) (values map[int]T, errs map[int]error) {
+	values, errs = map[int]T{}, map[int]error{}
+	for i, s := range AllSettled(ctx, promises) {
+		if s.Err != nil {
+			errs[i] = s.Err
+			continue
+		}
+		values[i] = s.Value
+	}
+	return values, errs
+}
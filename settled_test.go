@@ -0,0 +1,162 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAllSettledDeadline(t *testing.T) {
+	doh := errors.New("doh!")
+	results := AllSettledDeadline(context.Background(), time.Millisecond*30, []Promise[int]{
+		Resolve(1),
+		Reject[int](doh),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 200)
+			return 3, nil
+		}),
+	})
+	requireEqual(t, []Settled[int]{
+		{Value: 1},
+		{Err: doh},
+		{Err: context.DeadlineExceeded},
+	}, results)
+}
+
+func TestAllCancelable(t *testing.T) {
+	get, cancel := AllCancelable([]Promise[int]{
+		Resolve(1),
+		newCancelProbe[int](),
+	})
+	time.Sleep(time.Millisecond * 10)
+	cancel()
+	results, err := get()
+	requireEqual(t, context.Canceled, err)
+	requireEqual(t, []Settled[int]{{Value: 1}, {Err: context.Canceled}}, results)
+
+	// A promise that settles just as its await is cancelled still counts as
+	// interrupted, since its outcome is the cancellation.
+	late, cancelLate := AllCancelable([]Promise[string]{&lateSettler{}})
+	cancelLate()
+	lateResults, err := late()
+	requireEqual(t, context.Canceled, err)
+	requireEqual(t, context.Canceled, lateResults[0].Err)
+
+	get, cancel = AllCancelable([]Promise[int]{Resolve(1), Resolve(2)})
+	defer cancel()
+	results, err = get()
+	requireNoError(t, err)
+	requireEqual(t, []Settled[int]{{Value: 1}, {Value: 2}}, results)
+}
+
+func TestAllWithDefaults(t *testing.T) {
+	ctx := context.Background()
+	v, err := AllWithDefaults(ctx, []Promise[string]{
+		Resolve("live"),
+		Reject[string](errors.New("doh!")),
+		Resolve("also live"),
+	}, []string{"default 0", "default 1", "default 2"})
+	requireNoError(t, err)
+	requireEqual(t, []string{"live", "default 1", "also live"}, v)
+
+	_, err = AllWithDefaults(ctx, []Promise[string]{Resolve("a")}, nil)
+	requireError(t, err)
+}
+
+func TestAllSettled(t *testing.T) {
+	doh := errors.New("doh!")
+	results := AllSettled(context.Background(), []Promise[int]{
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 1, nil
+		}),
+		Reject[int](doh),
+		Resolve(3),
+	})
+	requireEqual(t, []Settled[int]{{Value: 1}, {Err: doh}, {Value: 3}}, results)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results = AllSettled[int](ctx, []Promise[int]{newCancelProbe[int]()})
+	requireEqual(t, []Settled[int]{{Err: context.Canceled}}, results)
+}
+
+func TestAllUntilIdle(t *testing.T) {
+	ctx := context.Background()
+	delayed := func(d time.Duration, v int) Promise[int] {
+		return NewPromise(func() (int, error) {
+			time.Sleep(d)
+			return v, nil
+		})
+	}
+	start := time.Now()
+	results, err := AllUntilIdle(ctx, []Promise[int]{
+		Resolve(1),
+		delayed(time.Millisecond*10, 2),
+		delayed(time.Millisecond*20, 3),
+		delayed(time.Second, 4),
+	}, time.Millisecond*50)
+	requireEqual(t, context.DeadlineExceeded, err)
+	requireEqual(t, []Settled[int]{
+		{Value: 1},
+		{Value: 2},
+		{Value: 3},
+		{Err: context.DeadlineExceeded},
+	}, results)
+	if elapsed := time.Since(start); elapsed > time.Millisecond*500 {
+		t.Fatalf("expected to give up once idle, but took %s", elapsed)
+	}
+
+	results, err = AllUntilIdle(ctx, []Promise[int]{Resolve(1)}, time.Second)
+	requireNoError(t, err)
+	requireEqual(t, []Settled[int]{{Value: 1}}, results)
+}
+
+func TestAllMaxErrors(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	probe := newCancelProbe[int]()
+	results, err := AllMaxErrors(ctx, 2, []Promise[int]{
+		Resolve(1),
+		Reject[int](doh),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 0, doh
+		}),
+		probe,
+	})
+	requireCancelled(t, probe)
+	requireEqual(t, true, errors.Is(err, ErrTooManyErrors))
+	requireEqual(t, true, errors.Is(err, doh))
+	requireEqual(t, []Settled[int]{
+		{Value: 1},
+		{Err: doh},
+		{Err: doh},
+		{Err: context.Canceled},
+	}, results)
+
+	results, err = AllMaxErrors(ctx, 2, []Promise[int]{
+		Resolve(1),
+		Reject[int](doh),
+		Resolve(3),
+	})
+	requireNoError(t, err)
+	requireEqual(t, []Settled[int]{{Value: 1}, {Err: doh}, {Value: 3}}, results)
+}
+
+func TestAllIndexedSettled(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	values, errs := AllIndexedSettled(ctx, []Promise[string]{
+		Resolve("a"),
+		Reject[string](doh),
+		Resolve("c"),
+	})
+	requireEqual(t, map[int]string{0: "a", 2: "c"}, values)
+	requireEqual(t, map[int]error{1: doh}, errs)
+
+	values, errs = AllIndexedSettled(ctx, []Promise[string]{Resolve("a")})
+	requireEqual(t, map[int]string{0: "a"}, values)
+	requireEqual(t, 0, len(errs))
+}
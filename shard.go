@@ -0,0 +1,48 @@
+package async
+
+import (
+	"context"
+)
+
+// Sharder routes work to one of a fixed number of serial queues based on a
+// key. All work for the same key runs one at a time, in the order it was
+// submitted, while work for keys on different shards runs concurrently. This
+// keeps per-entity ordering (like per-user event processing) with bounded
+// parallelism.
+type Sharder[K comparable, T any] struct {
+	shards []*Pool
+	keyFn  func(K) int
+}
+
+// NewSharder creates a Sharder with the given number of shards, or with a
+// single shard should shards not be positive. keyFn maps a key to a shard; its
+// result is taken modulo the number of shards, so any stable hash of the key
+// will do. A Sharder should be closed once it is no longer needed.
+func NewSharder[K comparable, T any](shards int, keyFn func(K) int) *Sharder[K, T] {
+	s := &Sharder[K, T]{
+		shards: make([]*Pool, max(shards, 1)),
+		keyFn:  keyFn,
+	}
+	for i := range s.shards {
+		s.shards[i] = NewPool(1)
+	}
+	return s
+}
+
+// Do queues fn on the shard for key, and returns a promise for its result. As
+// with Submit, fn is skipped if ctx is cancelled before its turn comes.
+func (s *Sharder[K, T]) Do(ctx context.Context, key K, fn func(context.Context) (T, error)) Promise[T] {
+	i := s.keyFn(key) % len(s.shards)
+	if i < 0 {
+		i += len(s.shards)
+	}
+	return Submit(ctx, s.shards[i], fn)
+}
+
+// Close stops the sharder from accepting new work, once the work already
+// queued has run.
+func (s *Sharder[K, T]) Close() {
+	for _, p := range s.shards {
+		p.Close()
+	}
+}
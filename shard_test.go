@@ -0,0 +1,49 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharder(t *testing.T) {
+	ctx := context.Background()
+	s := NewSharder[int, time.Time](4, func(k int) int { return k })
+	defer s.Close()
+
+	type span struct{ start, end time.Time }
+	var mu sync.Mutex
+	spans := map[string]span{}
+	task := func(name string) func(context.Context) (time.Time, error) {
+		return func(context.Context) (time.Time, error) {
+			start := time.Now()
+			time.Sleep(time.Millisecond * 30)
+			mu.Lock()
+			spans[name] = span{start, time.Now()}
+			mu.Unlock()
+			return start, nil
+		}
+	}
+	_, err := All(ctx, []Promise[time.Time]{
+		s.Do(ctx, 1, task("first")),
+		s.Do(ctx, 1, task("second")),
+		s.Do(ctx, 2, task("other")),
+	})
+	requireNoError(t, err)
+	if spans["second"].start.Before(spans["first"].end) {
+		t.Fatal("work for the same key overlapped")
+	}
+	if !spans["other"].start.Before(spans["first"].end) {
+		t.Fatal("work for different keys did not overlap")
+	}
+}
+
+func TestSharderWithoutShards(t *testing.T) {
+	ctx := context.Background()
+	s := NewSharder[string, int](0, func(key string) int { return len(key) })
+	defer s.Close()
+	v, err := s.Do(ctx, "key", func(context.Context) (int, error) { return 1, nil }).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+}
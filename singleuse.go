@@ -0,0 +1,58 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrAlreadyConsumed is returned by a SingleUse promise once its value has
+// already been delivered to another caller of Await.
+var ErrAlreadyConsumed = errors.New("async: promise value already consumed")
+
+type singleUsePromise[T any] struct {
+	p       Promise[T]
+	claimed atomic.Bool
+}
+
+func (s *singleUsePromise[T]) Settled() bool { return s.p.Settled() }
+
+func (s *singleUsePromise[T]) Await(ctx context.Context) (T, error) {
+	v, err := s.p.Await(ctx)
+	if err != nil {
+		return v, err
+	}
+	if !s.claimed.CompareAndSwap(false, true) {
+		var zerov T
+		return zerov, ErrAlreadyConsumed
+	}
+	return v, nil
+}
+
+// State reports the state of the promise without claiming its value. Once the
+// value has been claimed, the promise is Rejected, since any further Await
+// fails with ErrAlreadyConsumed.
+func (s *singleUsePromise[T]) State() State {
+	_, err, ok := s.TryAwait()
+	return stateOf(err, ok)
+}
+
+// TryAwait reports the outcome that Await would deliver right now, without
+// claiming the value, so that inspecting the promise never consumes it.
+func (s *singleUsePromise[T]) TryAwait() (T, error, bool) {
+	v, err, ok := TryAwait(s.p)
+	if ok && err == nil && s.claimed.Load() {
+		var zerov T
+		return zerov, ErrAlreadyConsumed, true
+	}
+	return v, err, ok
+}
+
+// SingleUse wraps p so that its value can be successfully awaited exactly once.
+// The first call to Await that receives the value claims it, and every
+// subsequent call returns ErrAlreadyConsumed. Errors (either from p or from the
+// context) do not claim the value. This is useful for promises that deliver a
+// resource which must not be used twice, like a single-use token.
+func SingleUse[T any](p Promise[T]) Promise[T] {
+	return &singleUsePromise[T]{p: p}
+}
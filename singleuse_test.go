@@ -0,0 +1,32 @@
+package async
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSingleUse(t *testing.T) {
+	ctx := context.Background()
+	promise := SingleUse(Resolve("token"))
+	v, err := promise.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "token", v)
+
+	_, err = promise.Await(ctx)
+	requireEqual(t, ErrAlreadyConsumed, err)
+}
+
+func TestSingleUseInspect(t *testing.T) {
+	ctx := context.Background()
+	token := SingleUse(Resolve("token"))
+	requireEqual(t, Fulfilled, StateOf(token))
+	_, _, ok := TryAwait(token)
+	requireEqual(t, true, ok)
+
+	v, err := token.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "token", v)
+	requireEqual(t, Rejected, StateOf(token))
+	_, err, _ = TryAwait(token)
+	requireEqual(t, ErrAlreadyConsumed, err)
+}
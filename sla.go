@@ -0,0 +1,91 @@
+package async
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+const slaTrackerSamples = 1024
+
+// SLATracker observes how long promises take to settle, and derives a timeout
+// from the distribution of those latencies. Only the most recent samples are
+// kept, so the timeout adapts as the behavior of the awaited work changes.
+// An SLATracker may also be built as a struct literal, setting Percentile and
+// Factor directly.
+type SLATracker struct {
+	// Percentile is the latency percentile (between 0 and 1) that the timeout
+	// is based upon.
+	Percentile float64
+
+	// Factor scales the percentile latency to produce the timeout, giving
+	// some headroom above the observed latency.
+	Factor float64
+
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+// NewSLATracker creates an SLATracker that times out awaits that take longer
+// than 1.5 times the observed p99 latency. Percentile and Factor may be
+// adjusted before the tracker is used.
+func NewSLATracker() *SLATracker {
+	return &SLATracker{
+		Percentile: 0.99,
+		Factor:     1.5,
+	}
+}
+
+// Record adds a latency sample to the tracker.
+func (s *SLATracker) Record(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.samples == nil {
+		s.samples = make([]time.Duration, 0, slaTrackerSamples)
+	}
+	if len(s.samples) < cap(s.samples) {
+		s.samples = append(s.samples, d)
+		return
+	}
+	s.samples[s.next] = d
+	s.next = (s.next + 1) % len(s.samples)
+}
+
+// Timeout is the timeout currently derived from the recorded latencies. If
+// nothing has been recorded yet, it returns zero, meaning no timeout.
+func (s *SLATracker) Timeout() time.Duration {
+	s.mu.Lock()
+	sorted := append([]time.Duration(nil), s.samples...)
+	s.mu.Unlock()
+	if len(sorted) == 0 {
+		return 0
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(s.Percentile*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return time.Duration(float64(sorted[rank]) * s.Factor)
+}
+
+// AwaitSLA awaits p with a timeout derived from the latencies tracker has
+// observed, so that slow outliers are cut off based on real behavior rather
+// than a guessed constant. If p settles in time, the time spent waiting on it
+// is recorded with tracker.
+func AwaitSLA[T any](tracker *SLATracker, p Promise[T]) (T, error) {
+	ctx := context.Background()
+	if timeout := tracker.Timeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	v, err := p.Await(ctx)
+	if p.Settled() {
+		tracker.Record(time.Since(start))
+	}
+	return v, err
+}
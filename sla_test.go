@@ -0,0 +1,32 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAwaitSLA(t *testing.T) {
+	tracker := NewSLATracker()
+	requireEqual(t, time.Duration(0), tracker.Timeout())
+	for i := 1; i <= 100; i++ {
+		tracker.Record(time.Duration(i) * time.Millisecond)
+	}
+	requireEqual(t, time.Duration(float64(99*time.Millisecond)*1.5), tracker.Timeout())
+
+	v, err := AwaitSLA(tracker, Resolve(1))
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+
+	_, err = AwaitSLA(tracker, NewPromise(func() (int, error) {
+		time.Sleep(time.Millisecond * 400)
+		return 2, nil
+	}))
+	requireEqual(t, context.DeadlineExceeded, err)
+}
+
+func TestSLATrackerLiteral(t *testing.T) {
+	tracker := &SLATracker{Percentile: 0.5, Factor: 2}
+	tracker.Record(time.Millisecond * 10)
+	requireEqual(t, time.Millisecond*20, tracker.Timeout())
+}
@@ -0,0 +1,32 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSpeculationFailed is returned by Speculate when the speculative result is
+// not confirmed.
+var ErrSpeculationFailed = errors.New("async: speculative result was not confirmed")
+
+// Speculate runs speculate in a new goroutine to produce a candidate result,
+// then runs confirm to validate it. The promise resolves with the candidate if
+// confirm approves of it, and rejects with ErrSpeculationFailed if it does not.
+// Errors from either function reject the promise as they are.
+func Speculate[T any](ctx context.Context, speculate func(context.Context) (T, error), confirm func(context.Context, T) (bool, error)) Promise[T] {
+	return NewPromise(func() (T, error) {
+		var zerov T
+		v, err := speculate(ctx)
+		if err != nil {
+			return zerov, err
+		}
+		ok, err := confirm(ctx, v)
+		if err != nil {
+			return zerov, err
+		}
+		if !ok {
+			return zerov, ErrSpeculationFailed
+		}
+		return v, nil
+	})
+}
@@ -0,0 +1,21 @@
+package async
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSpeculate(t *testing.T) {
+	ctx := context.Background()
+	guess := func(context.Context) (int, error) { return 42, nil }
+	v, err := Speculate(ctx, guess, func(_ context.Context, v int) (bool, error) {
+		return v == 42, nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 42, v)
+
+	_, err = Speculate(ctx, guess, func(_ context.Context, v int) (bool, error) {
+		return false, nil
+	}).Await(ctx)
+	requireEqual(t, ErrSpeculationFailed, err)
+}
@@ -0,0 +1,16 @@
+package async
+
+import (
+	"context"
+	"database/sql"
+)
+
+// QueryRow runs query against db in a new goroutine, with ctx, so that
+// cancelling ctx cancels the query, and resolves with the row as read by scan.
+// Should the query or scan fail, the promise rejects with the error; this
+// includes sql.ErrNoRows when the query matched nothing.
+func QueryRow[T any](ctx context.Context, db *sql.DB, scan func(*sql.Row) (T, error), query string, args ...any) Promise[T] {
+	return NewPromise(func() (T, error) {
+		return scan(db.QueryRowContext(ctx, query, args...))
+	})
+}
@@ -0,0 +1,81 @@
+package async
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeDriver is a database driver whose queries return a single row holding
+// the first argument of the query, or no rows at all if there are no
+// arguments.
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{values: args}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"v"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done || len(r.values) == 0 {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.values[0]
+	return nil
+}
+
+func init() {
+	sql.Register("async-fake", fakeDriver{})
+}
+
+func TestQueryRow(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("async-fake", "")
+	requireNoError(t, err)
+	defer db.Close()
+	scanInt := func(row *sql.Row) (int64, error) {
+		var v int64
+		err := row.Scan(&v)
+		return v, err
+	}
+
+	v, err := QueryRow(ctx, db, scanInt, "SELECT ?", int64(42)).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int64(42), v)
+
+	_, err = QueryRow(ctx, db, scanInt, "SELECT nothing").Await(ctx)
+	requireEqual(t, sql.ErrNoRows, err)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = QueryRow(cctx, db, scanInt, "SELECT ?", int64(42)).Await(ctx)
+	requireEqual(t, context.Canceled, err)
+}
@@ -0,0 +1,41 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnstable is returned by StableResult when no value is observed enough
+// times in a row before the attempts run out.
+var ErrUnstable = errors.New("async: result did not stabilize")
+
+// StableResult runs attempt repeatedly, up to maxAttempts times, until the
+// same value has been returned k times in a row, and returns that value. A
+// failed attempt breaks the run. This is for reading a value that may be in
+// flux, where it is only trusted once it has stopped changing. If the attempts
+// run out, ErrUnstable is returned, and if ctx is cancelled, its error is.
+func StableResult[T comparable](ctx context.Context, attempt func(context.Context) (T, error), k int, maxAttempts int) (T, error) {
+	var last T
+	run := 0
+	for i := 0; i < maxAttempts; i++ {
+		if err := ctx.Err(); err != nil {
+			var zerov T
+			return zerov, err
+		}
+		v, err := attempt(ctx)
+		switch {
+		case err != nil:
+			run = 0
+			continue
+		case run > 0 && v == last:
+			run++
+		default:
+			last, run = v, 1
+		}
+		if run >= k {
+			return v, nil
+		}
+	}
+	var zerov T
+	return zerov, ErrUnstable
+}
@@ -0,0 +1,33 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStableResult(t *testing.T) {
+	ctx := context.Background()
+	sequence := func(values ...string) func(context.Context) (string, error) {
+		i := 0
+		return func(context.Context) (string, error) {
+			v := values[i]
+			i++
+			if v == "" {
+				return "", errors.New("doh!")
+			}
+			return v, nil
+		}
+	}
+	v, err := StableResult(ctx, sequence("A", "B", "A", "A", "A"), 3, 5)
+	requireNoError(t, err)
+	requireEqual(t, "A", v)
+
+	_, err = StableResult(ctx, sequence("A", "A", "", "A", "B"), 3, 5)
+	requireEqual(t, ErrUnstable, err)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = StableResult(cctx, sequence("A"), 1, 1)
+	requireEqual(t, context.Canceled, err)
+}
@@ -0,0 +1,51 @@
+package async
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// MapStaggered runs fn over each of the items concurrently, but rather than
+// starting them all at once, spaces the start of each item at least stagger
+// apart, plus a random jitter of up to half of stagger. This smooths out the
+// load placed on a downstream service. Results are returned in input order,
+// and the first error short-circuits, cancelling the context given to fn and
+// preventing any further items from being started.
+func MapStaggered[T, U any](ctx context.Context, items []T, stagger time.Duration, fn func(context.Context, T) (U, error)) ([]U, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan outcome[U], len(items))
+	go func() {
+		for i, item := range items {
+			if i > 0 {
+				wait := stagger
+				if stagger > 1 {
+					wait += time.Duration(rand.Int63n(int64(stagger / 2)))
+				}
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					results <- outcome[U]{index: i, err: ctx.Err()}
+					return
+				case <-timer.C:
+				}
+			}
+			go func(i int, item T) {
+				v, err := safeCall(func() (U, error) { return fn(ctx, item) })
+				results <- outcome[U]{index: i, v: v, err: err}
+			}(i, item)
+		}
+	}()
+	out := make([]U, len(items))
+	for range items {
+		r := <-results
+		if r.err != nil {
+			return nil, r.err
+		}
+		out[r.index] = r.v
+	}
+	return out, nil
+}
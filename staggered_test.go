@@ -0,0 +1,32 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMapStaggered(t *testing.T) {
+	ctx := context.Background()
+	stagger := time.Millisecond * 20
+	starts, err := MapStaggered(ctx, []int{0, 1, 2, 3}, stagger, func(ctx context.Context, i int) (time.Time, error) {
+		return time.Now(), nil
+	})
+	requireNoError(t, err)
+	tolerance := time.Millisecond * 2
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i].Sub(starts[i-1]); gap < stagger-tolerance {
+			t.Fatalf("item %d started %s after item %d, expected at least %s", i, gap, i-1, stagger)
+		}
+	}
+
+	calls := 0
+	_, err = MapStaggered(ctx, []int{0, 1, 2}, stagger, func(ctx context.Context, i int) (int, error) {
+		calls++
+		return 0, errors.New("doh!")
+	})
+	requireError(t, err)
+	time.Sleep(stagger * 3)
+	requireEqual(t, 1, calls)
+}
@@ -0,0 +1,100 @@
+package async
+
+import (
+	"context"
+	"iter"
+)
+
+// Each returns a sequence that yields the outcome of each of the promises, as
+// a value and an error, in the order that they settle, so that results may be
+// handled as soon as they are ready. Nothing is awaited until the sequence is
+// ranged over, and breaking out of the range early cancels the awaits that are
+// still pending. Use StreamBounded to limit how far awaiting runs ahead of
+// the consumer.
+func Each[T any](ctx context.Context, promises []Promise[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		results := awaitEach(ctx, promises)
+		for range promises {
+			r := <-results
+			if !yield(r.v, r.err) {
+				return
+			}
+		}
+	}
+}
+
+// StreamBounded returns a sequence that yields the outcome of each of the
+// promises in the order that they settle, while holding no more than maxAhead
+// results ahead of the consumer. A promise is only awaited once there is room
+// for its result, so a slow consumer pauses the stream rather than having
+// results pile up in memory. A maxAhead below 1 is taken to be 1. Nothing is
+// awaited until the sequence is ranged over, and breaking out of the range
+// early cancels the awaits that are still pending. Should ctx be cancelled,
+// its error is yielded and the sequence ends.
+func StreamBounded[T any](ctx context.Context, promises []Promise[T], maxAhead int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		if maxAhead < 1 {
+			maxAhead = 1
+		}
+		slots := make(chan struct{}, maxAhead)
+		results := make(chan outcome[T], maxAhead)
+		go func() {
+			for i, p := range promises {
+				select {
+				case <-ctx.Done():
+					return
+				case slots <- struct{}{}:
+				}
+				go func() {
+					v, err := p.Await(ctx)
+					results <- outcome[T]{index: i, v: v, err: err}
+				}()
+			}
+		}()
+		for range promises {
+			select {
+			case <-ctx.Done():
+				var zerov T
+				yield(zerov, ctx.Err())
+				return
+			case r := <-results:
+				<-slots
+				if !yield(r.v, r.err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// StreamUntilError returns a sequence that yields the values of the promises
+// in the order that they settle, and ends quietly at the first failure, in the
+// manner of bufio.Scanner. Once ranging over the sequence is done, err reports
+// the failure that ended it, or nil if it ran to completion or the range was
+// broken out of. Nothing is awaited until the sequence is ranged over, and
+// the awaits of the remaining promises are cancelled once it ends.
+func StreamUntilError[T any](ctx context.Context, promises []Promise[T]) (seq iter.Seq[T], err func() error) {
+	var failure error
+	seq = func(yield func(T) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		failure = nil
+		results := awaitEach(ctx, promises)
+		for range promises {
+			r := <-results
+			if r.err != nil {
+				failure = r.err
+				return
+			}
+			if !yield(r.v) {
+				return
+			}
+		}
+	}
+	err = func() error { return failure }
+	return seq, err
+}
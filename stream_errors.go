@@ -0,0 +1,47 @@
+package async
+
+import (
+	"context"
+	"fmt"
+)
+
+// IndexedError is an error produced by one promise out of a group, tagged with
+// the position of that promise in the group.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+func (e IndexedError) Error() string {
+	return fmt.Sprintf("promise %d: %s", e.Index, e.Err.Error())
+}
+
+func (e IndexedError) Unwrap() error { return e.Err }
+
+// AllStreamErrors awaits all of the promises without short-circuiting. Each
+// failure is reported on errs as soon as it happens, so that a monitoring
+// goroutine may react to it, and errs is closed once every promise has
+// settled. The returned promise then resolves with the values in input order,
+// with the zero value in the slot of every promise that failed. errs is
+// buffered to fit every promise, so it need not be drained. The values come
+// as a promise rather than a slice because both results are returned by the
+// same call: were AllStreamErrors to block until every promise had settled,
+// errs could only ever be read after the fact.
+func AllStreamErrors[T any](ctx context.Context, promises []Promise[T]) (values Promise[[]T], errs <-chan IndexedError) {
+	errc := make(chan IndexedError, len(promises))
+	values = NewPromise(func() ([]T, error) {
+		defer close(errc)
+		out := make([]T, len(promises))
+		results := awaitEach(ctx, promises)
+		for range promises {
+			r := <-results
+			if r.err != nil {
+				errc <- IndexedError{Index: r.index, Err: r.err}
+				continue
+			}
+			out[r.index] = r.v
+		}
+		return out, nil
+	})
+	return values, errc
+}
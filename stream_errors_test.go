@@ -0,0 +1,30 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAllStreamErrors(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	values, errs := AllStreamErrors(ctx, []Promise[int]{
+		Resolve(1),
+		Reject[int](doh),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 3, nil
+		}),
+	})
+	failure := <-errs
+	requireEqual(t, 1, failure.Index)
+	requireEqual(t, true, errors.Is(failure, doh))
+
+	v, err := values.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, []int{1, 0, 3}, v)
+	_, open := <-errs
+	requireEqual(t, false, open)
+}
@@ -0,0 +1,107 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countedPromise is a settled promise that counts how many times it has been
+// awaited.
+type countedPromise struct {
+	v       int
+	awaited *atomic.Int32
+}
+
+func (c countedPromise) Settled() bool { return true }
+
+func (c countedPromise) Await(context.Context) (int, error) {
+	c.awaited.Add(1)
+	return c.v, nil
+}
+
+func TestStreamBounded(t *testing.T) {
+	ctx := context.Background()
+	var awaited atomic.Int32
+	promises := make([]Promise[int], 10)
+	for i := range promises {
+		promises[i] = countedPromise{v: i, awaited: &awaited}
+	}
+	const maxAhead = 3
+	var got []int
+	for v, err := range StreamBounded(ctx, promises, maxAhead) {
+		requireNoError(t, err)
+		got = append(got, v)
+		time.Sleep(time.Millisecond * 5)
+		if ahead := int(awaited.Load()) - len(got); ahead > maxAhead {
+			t.Fatalf("expected at most %d results ahead of the consumer, got %d", maxAhead, ahead)
+		}
+	}
+	requireEqual(t, 10, len(got))
+
+	doh := errors.New("doh!")
+	var errs []error
+	for _, err := range StreamBounded(ctx, []Promise[int]{Reject[int](doh), Resolve(1)}, 1) {
+		errs = append(errs, err)
+	}
+	requireEqual(t, 2, len(errs))
+
+	probe := newCancelProbe[int]()
+	for range StreamBounded(ctx, []Promise[int]{Resolve(1), probe}, 2) {
+		break
+	}
+	requireCancelled(t, probe)
+}
+
+func TestStreamUntilError(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	probe := newCancelProbe[int]()
+	seq, err := StreamUntilError(ctx, []Promise[int]{
+		Resolve(1),
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 0, doh
+		}),
+		probe,
+	})
+	var got []int
+	for v := range seq {
+		got = append(got, v)
+	}
+	requireEqual(t, []int{1}, got)
+	requireEqual(t, doh, err())
+	requireCancelled(t, probe)
+
+	seq, err = StreamUntilError(ctx, []Promise[int]{Resolve(1), Resolve(2)})
+	got = nil
+	for v := range seq {
+		got = append(got, v)
+	}
+	requireEqual(t, 2, len(got))
+	requireNoError(t, err())
+}
+
+func TestEach(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	var got []Settled[int]
+	for v, err := range Each(ctx, []Promise[int]{
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 1, nil
+		}),
+		Reject[int](doh),
+	}) {
+		got = append(got, Settled[int]{Value: v, Err: err})
+	}
+	requireEqual(t, []Settled[int]{{Err: doh}, {Value: 1}}, got)
+
+	probe := newCancelProbe[int]()
+	for range Each(ctx, []Promise[int]{Resolve(1), probe}) {
+		break
+	}
+	requireCancelled(t, probe)
+}
@@ -0,0 +1,101 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTooFewResults is returned by Take when the promises run out before the
+// requested number of successful results are collected.
+var ErrTooFewResults = errors.New("async: too few successful results")
+
+// ErrTooFewMatches is returned by AtLeast when the promises run out before
+// enough matching values are found.
+var ErrTooFewMatches = errors.New("async: too few matching results")
+
+// Take returns the first n successful results, in the order that they settled,
+// and cancels the awaits of the remaining promises once n have been collected.
+// Failed promises are skipped. If every promise settles before n successes are
+// collected, the successes gathered so far are returned along with
+// ErrTooFewResults, joined with the errors of the failed promises.
+func Take[T any](ctx context.Context, n int, promises []Promise[T]) ([]T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	out := make([]T, 0, n)
+	if n <= 0 {
+		return out, nil
+	}
+	errs := []error{ErrTooFewResults}
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		out = append(out, r.v)
+		if len(out) == n {
+			return out, nil
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// AtLeast collects the successful values of the promises for which match
+// reports true, in the order that they settled, and returns as soon as n of
+// them have been found, cancelling the awaits of the remaining promises. Failed
+// promises are skipped. If every promise settles before n matches are found,
+// the matches found so far are returned along with ErrTooFewMatches.
+func AtLeast[T any](ctx context.Context, promises []Promise[T], n int, match func(T) bool) ([]T, error) {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	if n <= 0 {
+		return []T{}, nil
+	}
+	out := make([]T, 0, n)
+	results := awaitEach(ctx, promises)
+	for range promises {
+		r := <-results
+		if r.err != nil || !match(r.v) {
+			continue
+		}
+		out = append(out, r.v)
+		if len(out) == n {
+			return out, nil
+		}
+	}
+	return out, ErrTooFewMatches
+}
+
+// Some returns the first n successful results, in the order that they settled,
+// and cancels the awaits of the remaining promises once n have been collected.
+// Unlike Take, it gives up as soon as so many promises have failed that n can
+// no longer be reached, cancelling the awaits of the rest, and returns the
+// successes gathered so far along with ErrTooFewResults, joined with the
+// errors of the failed promises.
+func Some[T any](ctx context.Context, n int, promises []Promise[T]) ([]T, error) {
+	if n <= 0 {
+		return []T{}, nil
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+	out := make([]T, 0, n)
+	errs := []error{ErrTooFewResults}
+	results := awaitEach(ctx, promises)
+	for remaining := len(promises); len(out)+remaining >= n; {
+		r := <-results
+		remaining--
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		out = append(out, r.v)
+		if len(out) == n {
+			return out, nil
+		}
+	}
+	return out, errors.Join(errs...)
+}
@@ -0,0 +1,61 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTake(t *testing.T) {
+	ctx := context.Background()
+	probe := newCancelProbe[int]()
+	v, err := Take(ctx, 2, []Promise[int]{
+		Resolve(1),
+		Reject[int](errors.New("skipped")),
+		Resolve(2),
+		probe,
+	})
+	requireNoError(t, err)
+	requireEqual(t, 2, len(v))
+	requireCancelled(t, probe)
+
+	v, err = Take(ctx, 3, []Promise[int]{Resolve(1), Reject[int](errors.New("doh!"))})
+	requireEqual(t, true, errors.Is(err, ErrTooFewResults))
+	requireEqual(t, []int{1}, v)
+}
+
+func TestAtLeast(t *testing.T) {
+	ctx := context.Background()
+	even := func(n int) bool { return n%2 == 0 }
+	probe := newCancelProbe[int]()
+	v, err := AtLeast(ctx, []Promise[int]{
+		Resolve(2),
+		Resolve(3),
+		Reject[int](errors.New("doh!")),
+		Resolve(4),
+		probe,
+	}, 2, even)
+	requireNoError(t, err)
+	requireEqual(t, 2, len(v))
+	requireCancelled(t, probe)
+
+	v, err = AtLeast(ctx, []Promise[int]{Resolve(2), Resolve(3), Resolve(5)}, 2, even)
+	requireEqual(t, ErrTooFewMatches, err)
+	requireEqual(t, []int{2}, v)
+}
+
+func TestSome(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	probe := newCancelProbe[int]()
+	v, err := Some(ctx, 2, []Promise[int]{Resolve(1), Reject[int](doh), Resolve(2), probe})
+	requireNoError(t, err)
+	requireEqual(t, 2, len(v))
+	requireCancelled(t, probe)
+
+	probe = newCancelProbe[int]()
+	_, err = Some(ctx, 2, []Promise[int]{Reject[int](doh), Reject[int](doh), probe})
+	requireEqual(t, true, errors.Is(err, ErrTooFewResults))
+	requireEqual(t, true, errors.Is(err, doh))
+	requireCancelled(t, probe)
+}
@@ -0,0 +1,133 @@
+package async
+
+import (
+	"context"
+)
+
+// thenPromise lazily applies fn to the outcome of src when it is awaited. The
+// mapping runs on the goroutine of the awaiting caller, at most one at a time,
+// and its result is shared with every other caller once it is known.
+type thenPromise[T, U any] struct {
+	src    Promise[T]
+	fn     func(context.Context, T, error) (U, error)
+	run    chan struct{}
+	result *syncPromise[U]
+}
+
+func newThenPromise[T, U any](src Promise[T], fn func(context.Context, T, error) (U, error)) *thenPromise[T, U] {
+	return &thenPromise[T, U]{
+		src:    src,
+		fn:     fn,
+		run:    make(chan struct{}, 1),
+		result: newSyncPromise[U](),
+	}
+}
+
+func (t *thenPromise[T, U]) Settled() bool { return t.result.Settled() }
+
+func (t *thenPromise[T, U]) Await(ctx context.Context) (U, error) {
+	var zerov U
+	if t.result.Settled() {
+		return t.result.Await(ctx)
+	}
+	v, srcErr := t.src.Await(ctx)
+	if srcErr != nil && !t.src.Settled() {
+		// the caller gave up before the source settled.
+		return zerov, srcErr
+	}
+	select {
+	case <-ctx.Done():
+		return zerov, ctx.Err()
+	case <-t.result.done:
+		return t.result.Await(ctx)
+	case t.run <- struct{}{}:
+	}
+	defer func() { <-t.run }()
+	if t.result.Settled() {
+		return t.result.Await(ctx)
+	}
+	u, err := t.fn(ctx, v, srcErr)
+	if err != nil && err != srcErr && ctx.Err() != nil && isContextError(err) {
+		// the mapping was interrupted by this caller giving up, which should
+		// not decide the result for everybody else.
+		return zerov, err
+	}
+	t.result.settle(u, err)
+	return u, err
+}
+
+// Then returns a promise that transforms the value of p with fn. The
+// transformation is lazy: only once the returned promise is awaited is p
+// awaited and, if it succeeded, fn called with its value. If p fails, fn is
+// skipped and its error is propagated. The returned promise is only settled
+// once both p has settled and fn has run, and fn runs at most once no matter
+// how many callers await the promise.
+func Then[T, U any](p Promise[T], fn func(T) (U, error)) Promise[U] {
+	return newThenPromise(p, func(_ context.Context, v T, err error) (U, error) {
+		if err != nil {
+			var zerov U
+			return zerov, err
+		}
+		return fn(v)
+	})
+}
+
+// ThenContext is like Then, except that fn is given the same context the
+// caller awaited with, so that fn may itself do cancellable work. Should fn
+// fail because the context of the caller running it was cancelled, the outcome
+// is not shared, and the next caller to await will run fn again.
+func ThenContext[T, U any](p Promise[T], fn func(context.Context, T) (U, error)) Promise[U] {
+	return newThenPromise(p, func(ctx context.Context, v T, err error) (U, error) {
+		if err != nil {
+			var zerov U
+			return zerov, err
+		}
+		return fn(ctx, v)
+	})
+}
+
+// Catch returns a promise that recovers from the failure of p with fn. Like
+// Then, it is lazy: once the returned promise is awaited, p is awaited and, if
+// it failed, fn is called with its error to produce a replacement result. If p
+// succeeds, its value is passed through without calling fn.
+func Catch[T any](p Promise[T], fn func(error) (T, error)) Promise[T] {
+	return newThenPromise(p, func(_ context.Context, v T, err error) (T, error) {
+		if err != nil {
+			return fn(err)
+		}
+		return v, nil
+	})
+}
+
+// Finally returns a promise that runs fn once p has settled, whether it
+// succeeded or failed, and then passes its result through unchanged. Like
+// Then, it is lazy, and fn runs at most once no matter how many callers await
+// the returned promise.
+func Finally[T any](p Promise[T], fn func()) Promise[T] {
+	return newThenPromise(p, func(_ context.Context, v T, err error) (T, error) {
+		fn()
+		return v, err
+	})
+}
+
+// ThenSlice is like Then, for a fn that expands the value of p into a slice of
+// results.
+func ThenSlice[T, U any](p Promise[T], fn func(T) ([]U, error)) Promise[[]U] {
+	return Then(p, fn)
+}
+
+// ExpandAll awaits all of the promises like All, then expands each of their
+// values with fn and concatenates the results, in input order, into a single
+// slice. If any promise fails, the others are cancelled and its error is
+// returned.
+func ExpandAll[T, U any](ctx context.Context, promises []Promise[T], fn func(T) []U) ([]U, error) {
+	values, err := All(ctx, promises)
+	if err != nil {
+		return nil, err
+	}
+	var out []U
+	for _, v := range values {
+		out = append(out, fn(v)...)
+	}
+	return out, nil
+}
@@ -0,0 +1,143 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestThenContext(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	promise := ThenContext(Resolve(21), func(ctx context.Context, v int) (string, error) {
+		calls++
+		return strconv.Itoa(v * 2), nil
+	})
+	requireEqual(t, false, promise.Settled())
+	v, err := promise.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "42", v)
+	requireEqual(t, true, promise.Settled())
+	_, _ = promise.Await(ctx)
+	requireEqual(t, 1, calls)
+
+	doh := errors.New("doh!")
+	_, err = ThenContext(Reject[int](doh), func(ctx context.Context, v int) (string, error) {
+		t.Error("fn should not be called when the source rejects")
+		return "", nil
+	}).Await(ctx)
+	requireEqual(t, doh, err)
+
+	observed := make(chan error, 1)
+	promise = ThenContext(Resolve(1), func(ctx context.Context, v int) (string, error) {
+		<-ctx.Done()
+		observed <- ctx.Err()
+		return "", ctx.Err()
+	})
+	cctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	_, err = promise.Await(cctx)
+	requireEqual(t, context.DeadlineExceeded, err)
+	requireEqual(t, context.DeadlineExceeded, <-observed)
+	requireEqual(t, false, promise.Settled())
+}
+
+func TestThen(t *testing.T) {
+	ctx := context.Background()
+	block := make(chan struct{})
+	src := NewPromise(func() (int, error) {
+		<-block
+		return 2, nil
+	})
+	calls := 0
+	promise := Then(src, func(v int) (string, error) {
+		calls++
+		return strconv.Itoa(v), nil
+	})
+
+	cctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	_, err := promise.Await(cctx)
+	requireEqual(t, context.DeadlineExceeded, err)
+	requireEqual(t, false, promise.Settled())
+
+	close(block)
+	v, err := promise.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "2", v)
+	requireEqual(t, true, promise.Settled())
+	_, _ = promise.Await(ctx)
+	requireEqual(t, 1, calls)
+
+	doh := errors.New("doh!")
+	_, err = Then(Reject[int](doh), func(v int) (string, error) {
+		t.Error("fn should not be called when the source rejects")
+		return "", nil
+	}).Await(ctx)
+	requireEqual(t, doh, err)
+}
+
+func TestCatch(t *testing.T) {
+	ctx := context.Background()
+	v, err := Catch(Reject[string](errors.New("doh!")), func(err error) (string, error) {
+		return "recovered from " + err.Error(), nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "recovered from doh!", v)
+
+	v, err = Catch(Resolve("fine"), func(err error) (string, error) {
+		t.Error("fn should not be called when the source resolves")
+		return "", nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "fine", v)
+}
+
+func TestThenSlice(t *testing.T) {
+	ctx := context.Background()
+	v, err := ThenSlice(Resolve("a,b"), func(s string) ([]string, error) {
+		return []string{s[:1], s[2:]}, nil
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, []string{"a", "b"}, v)
+}
+
+func TestExpandAll(t *testing.T) {
+	ctx := context.Background()
+	pair := func(n int) []int { return []int{n, n * 10} }
+	v, err := ExpandAll(ctx, []Promise[int]{
+		NewPromise(func() (int, error) {
+			time.Sleep(time.Millisecond * 20)
+			return 1, nil
+		}),
+		Resolve(2),
+		Resolve(3),
+	}, pair)
+	requireNoError(t, err)
+	requireEqual(t, []int{1, 10, 2, 20, 3, 30}, v)
+
+	probe := newCancelProbe[int]()
+	_, err = ExpandAll(ctx, []Promise[int]{Reject[int](errors.New("doh!")), probe}, pair)
+	requireError(t, err)
+	requireCancelled(t, probe)
+}
+
+func TestFinally(t *testing.T) {
+	ctx := context.Background()
+	runs := 0
+	p := Finally(Resolve("fine"), func() { runs++ })
+	requireEqual(t, 0, runs)
+	for i := 0; i < 2; i++ {
+		v, err := p.Await(ctx)
+		requireNoError(t, err)
+		requireEqual(t, "fine", v)
+	}
+	requireEqual(t, 1, runs)
+
+	doh := errors.New("doh!")
+	_, err := Finally(Reject[string](doh), func() { runs++ }).Await(ctx)
+	requireEqual(t, doh, err)
+	requireEqual(t, 2, runs)
+}
@@ -0,0 +1,28 @@
+package async
+
+import (
+	"context"
+	"errors"
+)
+
+// Tiered tries each tier in order, only invoking a tier once all of the tiers
+// before it have failed. It returns the first successful value along with the
+// index of the tier that produced it, which tells the caller how degraded the
+// result is (0 being the best). If every tier fails, the index is -1 and the
+// joined errors of all tiers are returned.
+func Tiered[T any](ctx context.Context, tiers ...func(context.Context) (T, error)) (T, int, error) {
+	errs := make([]error, 0, len(tiers))
+	for i, tier := range tiers {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		v, err := tier(ctx)
+		if err == nil {
+			return v, i, nil
+		}
+		errs = append(errs, err)
+	}
+	var zerov T
+	return zerov, -1, errors.Join(errs...)
+}
@@ -0,0 +1,36 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTiered(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	v, tier, err := Tiered(ctx,
+		func(context.Context) (string, error) {
+			calls++
+			return "", errors.New("cache miss")
+		},
+		func(context.Context) (string, error) {
+			calls++
+			return "database", nil
+		},
+		func(context.Context) (string, error) {
+			calls++
+			return "static default", nil
+		},
+	)
+	requireNoError(t, err)
+	requireEqual(t, "database", v)
+	requireEqual(t, 1, tier)
+	requireEqual(t, 2, calls)
+
+	_, tier, err = Tiered(ctx, func(context.Context) (string, error) {
+		return "", errors.New("nope")
+	})
+	requireError(t, err)
+	requireEqual(t, -1, tier)
+}
@@ -0,0 +1,105 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TimeoutError is the error a promise returned by WithTimeout is rejected with
+// when the wrapped promise takes too long to settle. It satisfies os.IsTimeout,
+// and errors.Is(err, context.DeadlineExceeded).
+type TimeoutError struct {
+	// After is how long the promise was given to settle.
+	After time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("async: promise timed out after %s", e.After)
+}
+
+// Timeout reports that this is a timeout, for os.IsTimeout.
+func (e *TimeoutError) Timeout() bool { return true }
+
+func (e *TimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+type timeoutPromise[T any] struct {
+	src       Promise[T]
+	d         time.Duration
+	deadline  time.Time
+	onTimeout func(late T, err error)
+	start     sync.Once
+	result    *syncPromise[T]
+}
+
+func (t *timeoutPromise[T]) Settled() bool { return t.result.Settled() }
+
+func (t *timeoutPromise[T]) Await(ctx context.Context) (T, error) {
+	t.start.Do(func() {
+		if !t.deadline.IsZero() {
+			t.d = t.deadline.Sub(now())
+		}
+		go func() {
+			tctx, cancel := withClockTimeout(context.Background(), t.d)
+			defer cancel()
+			v, err := t.src.Await(tctx)
+			if err != nil && tctx.Err() != nil && errors.Is(err, tctx.Err()) {
+				// The timeout fired, even if src has settled since.
+				t.result.settle(v, &TimeoutError{After: t.d})
+				if t.onTimeout != nil {
+					late, lateErr := t.src.Await(context.Background())
+					// The promise has already settled, so a panicking
+					// cleanup has no one to report to; it must not crash
+					// the program from this goroutine either.
+					safeCall(func() (struct{}, error) {
+						t.onTimeout(late, lateErr)
+						return struct{}{}, nil
+					})
+				}
+				return
+			}
+			t.result.settle(v, err)
+		}()
+	})
+	return t.result.Await(ctx)
+}
+
+// WithTimeout returns a promise that settles with the result of p if it
+// settles within d, and otherwise rejects with a *TimeoutError. Unlike
+// awaiting with a context that has a deadline, the timeout applies to the
+// promise itself, so every caller awaiting it sees the same outcome. The timer
+// only starts once the returned promise is first awaited, so a promise that is
+// never awaited holds onto no timer.
+func WithTimeout[T any](p Promise[T], d time.Duration) Promise[T] {
+	return &timeoutPromise[T]{
+		src:    p,
+		d:      d,
+		result: newSyncPromise[T](),
+	}
+}
+
+// WithDeadline is like WithTimeout, except that p is given until the deadline
+// to settle. The *TimeoutError records how long p was given, as of the moment
+// the returned promise was first awaited.
+func WithDeadline[T any](p Promise[T], deadline time.Time) Promise[T] {
+	return &timeoutPromise[T]{
+		src:      p,
+		deadline: deadline,
+		result:   newSyncPromise[T](),
+	}
+}
+
+// TimeoutCleanup is like WithTimeout, except that should p settle after the
+// timeout, onTimeout is called with its late result, so that whatever the
+// abandoned work produced, such as a connection, can be released rather than
+// leaked.
+func TimeoutCleanup[T any](p Promise[T], d time.Duration, onTimeout func(late T, err error)) Promise[T] {
+	return &timeoutPromise[T]{
+		src:       p,
+		d:         d,
+		onTimeout: onTimeout,
+		result:    newSyncPromise[T](),
+	}
+}
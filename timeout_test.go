@@ -0,0 +1,113 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithTimeout(t *testing.T) {
+	ctx := context.Background()
+	v, err := WithTimeout(Resolve(1), time.Millisecond*10).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+
+	promise := WithTimeout[int](newCancelProbe[int](), time.Millisecond*10)
+	time.Sleep(time.Millisecond * 20)
+	requireEqual(t, false, promise.Settled()) // the timer has not started yet
+	_, err = promise.Await(ctx)
+	var timeoutErr *TimeoutError
+	requireEqual(t, true, errors.As(err, &timeoutErr))
+	requireEqual(t, true, os.IsTimeout(err))
+	requireEqual(t, true, errors.Is(err, context.DeadlineExceeded))
+	requireEqual(t, true, promise.Settled())
+
+	doh := errors.New("doh!")
+	_, err = WithTimeout(Reject[int](doh), time.Second).Await(ctx)
+	requireEqual(t, doh, err)
+}
+
+func TestTimeoutCleanup(t *testing.T) {
+	ctx := context.Background()
+	late := make(chan string, 1)
+	promise := TimeoutCleanup(NewPromise(func() (string, error) {
+		time.Sleep(time.Millisecond * 30)
+		return "late connection", nil
+	}), time.Millisecond*10, func(v string, err error) {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		late <- v
+	})
+	_, err := promise.Await(ctx)
+	requireEqual(t, true, os.IsTimeout(err))
+	select {
+	case v := <-late:
+		requireEqual(t, "late connection", v)
+	case <-time.After(time.Second):
+		t.Fatal("expected onTimeout to be called with the late result")
+	}
+
+	v, err := TimeoutCleanup(Resolve("on time"), time.Second, func(string, error) {
+		t.Error("onTimeout should not be called when the promise settles in time")
+	}).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "on time", v)
+}
+
+// lateSettler is a promise that settles just as its await times out, so that
+// it has already settled by the time the await returns the context error.
+type lateSettler struct {
+	settled atomic.Bool
+}
+
+func (l *lateSettler) Settled() bool { return l.settled.Load() }
+
+func (l *lateSettler) Await(ctx context.Context) (string, error) {
+	if !l.settled.Load() {
+		<-ctx.Done()
+		l.settled.Store(true)
+		return "", ctx.Err()
+	}
+	return "late connection", nil
+}
+
+func TestTimeoutCleanupSettledDuringTimeout(t *testing.T) {
+	ctx := context.Background()
+	late := make(chan string, 1)
+	_, err := TimeoutCleanup[string](&lateSettler{}, time.Millisecond, func(v string, err error) {
+		late <- v
+	}).Await(ctx)
+	requireEqual(t, true, os.IsTimeout(err))
+	select {
+	case v := <-late:
+		requireEqual(t, "late connection", v)
+	case <-time.After(time.Second):
+		t.Fatal("expected onTimeout to be called with the late result")
+	}
+
+	cleaned := make(chan struct{})
+	_, err = TimeoutCleanup[string](&lateSettler{}, time.Millisecond, func(string, error) {
+		defer close(cleaned)
+		panic("cleanup exploded")
+	}).Await(ctx)
+	requireEqual(t, true, os.IsTimeout(err))
+	<-cleaned
+}
+
+func TestWithDeadline(t *testing.T) {
+	ctx := context.Background()
+	v, err := WithDeadline(Resolve(1), time.Now().Add(time.Second)).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+
+	_, err = WithDeadline[int](newCancelProbe[int](), time.Now().Add(time.Millisecond*10)).Await(ctx)
+	requireEqual(t, true, os.IsTimeout(err))
+	requireEqual(t, true, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = WithDeadline[int](newCancelProbe[int](), time.Now().Add(-time.Second)).Await(ctx)
+	requireEqual(t, true, os.IsTimeout(err))
+}
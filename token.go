@@ -0,0 +1,61 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenSource hands out a shared, periodically refreshed value, such as an
+// access token. While the current token is valid, or a refresh is in flight,
+// every caller shares the same promise, so a refresh only ever runs once no
+// matter how many callers need a new token at the same time.
+type TokenSource[T any] struct {
+	refresh func(context.Context) (T, time.Duration, error)
+
+	mu      sync.Mutex
+	current *syncPromise[T]
+	expires time.Time
+}
+
+// NewTokenSource creates a TokenSource that obtains tokens with refresh, which
+// returns the token along with how long it remains valid for.
+func NewTokenSource[T any](refresh func(ctx context.Context) (T, time.Duration, error)) *TokenSource[T] {
+	return &TokenSource[T]{refresh: refresh}
+}
+
+// Token returns a promise for a valid token, starting a refresh if the current
+// one has expired. A refresh is given ctx with its cancellation removed, since
+// other callers may come to share it. Should the refresh fail, every caller
+// sharing it is rejected with the error, and the next call to Token starts a
+// new refresh.
+func (s *TokenSource[T]) Token(ctx context.Context) Promise[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != nil && (!s.current.Settled() || time.Now().Before(s.expires)) {
+		return s.current
+	}
+	p := newSyncPromise[T]()
+	s.current = p
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		var ttl time.Duration
+		v, err := safeCall(func() (T, error) {
+			var v T
+			var err error
+			v, ttl, err = s.refresh(ctx)
+			return v, err
+		})
+		s.mu.Lock()
+		if err != nil {
+			if s.current == p {
+				s.current = nil
+			}
+		} else {
+			s.expires = time.Now().Add(ttl)
+		}
+		s.mu.Unlock()
+		p.settle(v, err)
+	}()
+	return p
+}
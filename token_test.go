@@ -0,0 +1,59 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenSource(t *testing.T) {
+	ctx := context.Background()
+	var refreshes atomic.Int32
+	src := NewTokenSource(func(context.Context) (int32, time.Duration, error) {
+		time.Sleep(time.Millisecond * 10)
+		return refreshes.Add(1), time.Millisecond * 50, nil
+	})
+	v, err := src.Token(ctx).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int32(1), v)
+	v, err = src.Token(ctx).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, int32(1), v)
+
+	time.Sleep(time.Millisecond * 60)
+	var wg sync.WaitGroup
+	results := make([]Settled[int32], 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := src.Token(ctx).Await(ctx)
+			results[i] = Settled[int32]{Value: v, Err: err}
+		}(i)
+	}
+	wg.Wait()
+	requireEqual(t, int32(2), refreshes.Load())
+	for _, r := range results {
+		requireEqual(t, Settled[int32]{Value: 2}, r)
+	}
+}
+
+func TestTokenSourceFailure(t *testing.T) {
+	ctx := context.Background()
+	doh := errors.New("doh!")
+	var calls atomic.Int32
+	src := NewTokenSource(func(context.Context) (string, time.Duration, error) {
+		if calls.Add(1) == 1 {
+			return "", 0, doh
+		}
+		return "token", time.Minute, nil
+	})
+	_, err := src.Token(ctx).Await(ctx)
+	requireEqual(t, doh, err)
+	v, err := src.Token(ctx).Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "token", v)
+}
@@ -0,0 +1,57 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+var abandoned atomic.Int64
+
+// Abandoned reports how many goroutines started by Uninterruptible are still
+// running even though a caller has given up awaiting their result. This is
+// meant for monitoring goroutine leaks.
+func Abandoned() int {
+	return int(abandoned.Load())
+}
+
+type uninterruptiblePromise[T any] struct {
+	*syncPromise[T]
+	mu        sync.Mutex
+	finished  bool
+	abandoned bool
+}
+
+func (u *uninterruptiblePromise[T]) Await(ctx context.Context) (T, error) {
+	v, err := u.syncPromise.Await(ctx)
+	if err != nil && !u.Settled() {
+		u.mu.Lock()
+		if !u.finished && !u.abandoned {
+			u.abandoned = true
+			abandoned.Add(1)
+		}
+		u.mu.Unlock()
+	}
+	return v, err
+}
+
+// Uninterruptible runs fn, an inherently blocking operation that cannot be
+// cancelled (like a read from a net.Conn without a deadline), in a new
+// goroutine. Awaiting the returned promise may be cancelled with a context
+// like any other, but doing so only abandons the goroutine running fn: it
+// carries on, and leaks, until fn returns by itself. Goroutines that have been
+// abandoned this way are counted by Abandoned until they finish.
+func Uninterruptible[T any](fn func() (T, error)) Promise[T] {
+	u := &uninterruptiblePromise[T]{syncPromise: newSyncPromise[T]()}
+	go func() {
+		v, err := safeCall(fn)
+		u.mu.Lock()
+		u.finished = true
+		if u.abandoned {
+			abandoned.Add(-1)
+		}
+		u.mu.Unlock()
+		u.settle(v, err)
+	}()
+	return u
+}
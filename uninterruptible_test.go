@@ -0,0 +1,33 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestUninterruptible(t *testing.T) {
+	block := make(chan struct{})
+	promise := Uninterruptible(func() (int, error) {
+		<-block
+		return 1, nil
+	})
+	before := Abandoned()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	_, err := promise.Await(ctx)
+	requireEqual(t, context.Canceled, err)
+	if time.Since(start) > time.Millisecond*100 {
+		t.Fatal("cancelled await did not return promptly")
+	}
+	requireEqual(t, before+1, Abandoned())
+	_, _ = promise.Await(ctx)
+	requireEqual(t, before+1, Abandoned())
+
+	close(block)
+	v, err := promise.Await(context.Background())
+	requireNoError(t, err)
+	requireEqual(t, 1, v)
+	requireEqual(t, before, Abandoned())
+}
@@ -0,0 +1,59 @@
+package async
+
+import (
+	"context"
+	"sync"
+)
+
+// WarmCache caches the promise for each key it is asked for, and can be
+// pre-populated with known values.
+type WarmCache[K comparable, V any] struct {
+	fn func(context.Context, K) (V, error)
+
+	mu      sync.Mutex
+	entries map[K]Promise[V]
+}
+
+// NewWarmCache creates a WarmCache that loads missing keys with fn.
+func NewWarmCache[K comparable, V any](fn func(ctx context.Context, key K) (V, error)) *WarmCache[K, V] {
+	return &WarmCache[K, V]{
+		fn:      fn,
+		entries: make(map[K]Promise[V]),
+	}
+}
+
+// Get returns the cached promise for key, or if there is none, starts loading
+// key in a new goroutine and caches the promise for that. Every caller of Get
+// with the same key shares the same promise while it is loading. A load that
+// failed is not kept: the next call to Get loads the key again. The key is
+// loaded with the values, but not the cancellation, of ctx, since other
+// callers share the load; each caller can still give up by cancelling its own
+// await.
+func (c *WarmCache[K, V]) Get(ctx context.Context, key K) Promise[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.entries[key]; ok {
+		if _, err, settled := TryAwait(p); !settled || err == nil {
+			return p
+		}
+	}
+	ctx = context.WithoutCancel(ctx)
+	p := NewPromise(func() (V, error) {
+		return c.fn(ctx, key)
+	})
+	c.entries[key] = p
+	return p
+}
+
+// Warm stores value as the already settled result for key, so that Get
+// returns it right away without calling fn. A key that is still being loaded
+// is left alone, since the computation in flight has callers waiting on it;
+// a key that has already settled is overwritten.
+func (c *WarmCache[K, V]) Warm(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.entries[key]; ok && !p.Settled() {
+		return
+	}
+	c.entries[key] = Resolve(value)
+}
@@ -0,0 +1,57 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmCache(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	block := make(chan struct{})
+	cache := NewWarmCache(func(ctx context.Context, key string) (string, error) {
+		calls.Add(1)
+		<-block
+		return "loaded " + key, nil
+	})
+	cache.Warm("hot", "preloaded")
+	promise := cache.Get(ctx, "hot")
+	requireEqual(t, true, promise.Settled())
+	v, err := promise.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "preloaded", v)
+	requireEqual(t, int32(0), calls.Load())
+
+	cold := cache.Get(ctx, "cold")
+	cache.Warm("cold", "ignored")
+	close(block)
+	v, err = cache.Get(ctx, "cold").Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "loaded cold", v)
+	requireEqual(t, cold, cache.Get(ctx, "cold"))
+	requireEqual(t, int32(1), calls.Load())
+}
+
+func TestWarmCacheFailure(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	cache := NewWarmCache(func(ctx context.Context, key string) (string, error) {
+		if calls.Add(1) == 1 {
+			return "", errors.New("doh!")
+		}
+		return "loaded " + key, ctx.Err()
+	})
+	_, err := cache.Get(ctx, "k").Await(ctx)
+	requireError(t, err)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	p := cache.Get(cancelled, "k")
+	v, err := p.Await(ctx)
+	requireNoError(t, err)
+	requireEqual(t, "loaded k", v)
+	requireEqual(t, p, cache.Get(ctx, "k"))
+	requireEqual(t, int32(2), calls.Load())
+}